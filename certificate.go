@@ -3,6 +3,8 @@ package ctlint
 import (
 	"crypto/sha256"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/google/certificate-transparency-go/asn1"
 	"github.com/google/certificate-transparency-go/x509"
@@ -14,26 +16,74 @@ const (
 	unknown CTPolicyGroup = iota
 	ServerAuthenticationCertificate
 	MarkCertificate
+	SMIMECertificate
+	CodeSigningCertificate
 )
 
+func (policyGroup CTPolicyGroup) String() string {
+	switch policyGroup {
+	case ServerAuthenticationCertificate:
+		return "Server Authentication Certificate"
+	case MarkCertificate:
+		return "Mark Certificate"
+	case SMIMECertificate:
+		return "S/MIME Certificate"
+	case CodeSigningCertificate:
+		return "Code Signing Certificate"
+	default:
+		return "Certificate"
+	}
+}
+
 var OIDExtensionOCSPCTSCT = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 5}
 var OIDEKUBrandIndicatorforMessageIdentification asn1.ObjectIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 31}
 
 func CheckCertificate(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, policyGroup_optional ...CTPolicyGroup) []string {
+	return checkCertificate(cert, sha256IssuerSPKI, CCADBIssuerResolver{}, policyGroup_optional, nil)
+}
+
+// CheckCertificateWithIssuer is like CheckCertificate, except that it first checks that issuer actually issued cert.  If it did not, the issuer SPKI is instead sought in the available CCADB data.
+func CheckCertificateWithIssuer(cert *x509.Certificate, issuer *x509.Certificate, policyGroup_optional ...CTPolicyGroup) []string {
+	if cert == nil || issuer == nil {
+		return CheckCertificate(cert, nil, policyGroup_optional...)
+	}
+
+	findings, isIssuer := checkIssuer(cert, issuer)
+	if !isIssuer {
+		findings = append(findings, "W: Supplied issuer certificate did not issue this certificate, so it will not be used to verify SCT signatures")
+		return append(findings, CheckCertificate(cert, nil, policyGroup_optional...)...)
+	}
+
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	return append(findings, checkCertificate(cert, &sha256IssuerSPKI, CCADBIssuerResolver{}, policyGroup_optional, issuer)...)
+}
+
+// CheckCertificateWithIssuerResolver is like CheckCertificate, except that the issuer SPKI is determined by the specified IssuerResolver.
+func CheckCertificateWithIssuerResolver(cert *x509.Certificate, issuerResolver IssuerResolver, policyGroup_optional ...CTPolicyGroup) []string {
+	return checkCertificate(cert, nil, issuerResolver, policyGroup_optional, nil)
+}
+
+func checkCertificate(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, policyGroup_optional []CTPolicyGroup, issuer *x509.Certificate) []string {
 	var findings []string
 
 	if cert == nil {
 		findings = append(findings, "E: Certificate not provided")
 	} else if !cert.IsCA {
-		policyGroup, policyGroupDescription := getPolicyGroup(cert, policyGroup_optional)
-		sctListExtCount := 0
+		policyGroup, policyGroupDescription, policyGroupExplanation := getPolicyGroup(cert, policyGroup_optional, issuer)
+		sctListExtCount, transparencyInfoExtCount := 0, 0
 		for _, ext := range cert.Extensions {
 			if ext.Id.Equal(x509.OIDExtensionCTSCT) {
 				sctListExtCount++
 				if sctListExtCount > 1 {
 					findings = append(findings, "E: Multiple SCT list extensions are present")
 				}
-				findings = append(findings, checkSCTListExtension(cert, policyGroup, sha256IssuerSPKI, ext)...)
+				findings = append(findings, checkSCTListExtension(cert, policyGroup, sha256IssuerSPKI, issuerResolver, ext)...)
+			} else if ext.Id.Equal(OIDExtensionTransparencyInformation) {
+				transparencyInfoExtCount++
+				if transparencyInfoExtCount > 1 {
+					findings = append(findings, "E: Multiple transparency information extensions are present")
+				}
+				findings = append(findings, checkTransparencyInformationExtension(cert, sha256IssuerSPKI, issuerResolver, ext)...)
 			} else if ext.Id.Equal(x509.OIDExtensionCTPoison) {
 				findings = append(findings, "E: Precertificate 'poison' extension is present")
 			} else if ext.Id.Equal(OIDExtensionOCSPCTSCT) {
@@ -41,7 +91,14 @@ func CheckCertificate(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byt
 			}
 		}
 
-		if sctListExtCount == 0 {
+		// The explanation is only reported when a CT Policy group was identified, because otherwise there is nothing to explain, and the explanation could include text (such as an issuer's name) taken from the certificate chain.
+		var identifiedFindings []string
+		if policyGroup != unknown {
+			identifiedFindings = append(identifiedFindings, fmt.Sprintf("I: Identified as a %s, because the %s", policyGroupDescription, policyGroupExplanation))
+		}
+
+		if sctListExtCount == 0 && transparencyInfoExtCount == 0 {
+			findings = append(findings, identifiedFindings...)
 			switch policyGroup {
 			case ServerAuthenticationCertificate:
 				findings = append(findings, fmt.Sprintf("N: SCT list extension is absent in this %s", policyGroupDescription))
@@ -51,49 +108,104 @@ func CheckCertificate(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byt
 				findings = append(findings, fmt.Sprintf("I: No CT policies apply to this %s", policyGroupDescription))
 			}
 		} else {
-			findings = append([]string{fmt.Sprintf("I: %s with embedded SCT list identified", policyGroupDescription)}, findings...)
+			findings = append(append([]string{fmt.Sprintf("I: %s with embedded SCT list identified", policyGroupDescription)}, identifiedFindings...), findings...)
+		}
+
+		if sctListExtCount > 0 && transparencyInfoExtCount > 0 {
+			findings = append(findings, "I: Certificate contains both RFC6962 (v1) and RFC9162 (v2) SCTs")
+		} else if transparencyInfoExtCount > 0 {
+			findings = append(findings, "N: Certificate contains only RFC9162 (v2) SCTs, which are not recognized by any CT Policy")
 		}
 	} else {
-		for _, eku := range cert.ExtKeyUsage {
-			if eku == x509.ExtKeyUsageCertificateTransparency {
-				findings = append([]string{"I: Precertificate Signing Certificate identified"}, findings...)
-				break
-			}
+		if hasCTEKU(cert) {
+			findings = append([]string{"I: Precertificate Signing Certificate identified"}, findings...)
 		}
 	}
 
 	return findings
 }
 
-func getPolicyGroup(cert *x509.Certificate, policyGroup_optional []CTPolicyGroup) (CTPolicyGroup, string) {
-	policyGroup := detectPolicyGroup(cert, policyGroup_optional)
-	switch policyGroup {
-	case ServerAuthenticationCertificate:
-		return policyGroup, "Server Authentication Certificate"
-	case MarkCertificate:
-		return policyGroup, "Mark Certificate"
-	default:
-		return policyGroup, "Certificate"
+func getPolicyGroup(cert *x509.Certificate, policyGroup_optional []CTPolicyGroup, issuers ...*x509.Certificate) (CTPolicyGroup, string, string) {
+	var policyGroup CTPolicyGroup
+	var explanation string
+	if len(policyGroup_optional) > 0 {
+		policyGroup, explanation = policyGroup_optional[0], "policy group was specified by the caller"
+	} else {
+		policyGroup, explanation = DetectPolicyGroup(cert, issuers...)
 	}
+
+	return policyGroup, policyGroup.String(), explanation
 }
 
-func detectPolicyGroup(cert *x509.Certificate, policyGroup_optional []CTPolicyGroup) CTPolicyGroup {
-	if len(policyGroup_optional) > 0 {
-		return policyGroup_optional[0]
+// DetectPolicyGroup determines which CTPolicyGroup a certificate belongs to, and explains why.
+// The issuer certificates, if provided (starting with cert's issuer), are consulted for EKU restrictions that limit what the certificate can be used for.
+func DetectPolicyGroup(cert *x509.Certificate, issuers ...*x509.Certificate) (CTPolicyGroup, string) {
+	hasEKUExtension := len(cert.ExtKeyUsage) > 0 || len(cert.UnknownExtKeyUsage) > 0
+	permitsEKU := func(eku x509.ExtKeyUsage, unknownEKU asn1.ObjectIdentifier) (bool, string) {
+		for _, issuer := range issuers {
+			if issuer != nil && !hasEKU(issuer, x509.ExtKeyUsageAny, nil) && (len(issuer.ExtKeyUsage) > 0 || len(issuer.UnknownExtKeyUsage) > 0) && !hasEKU(issuer, eku, unknownEKU) {
+				return false, fmt.Sprintf("the EKU extension of issuer %q does not permit it", issuer.Subject.CommonName)
+			}
+		}
+		return true, ""
+	}
+
+	var reasons []string
+	if !hasEKUExtension {
+		// Browsers treat a certificate without an EKU extension as usable for server authentication, unless it is clearly intended for S/MIME.
+		if permitted, reason := permitsEKU(x509.ExtKeyUsageServerAuth, nil); !permitted {
+			reasons = append(reasons, "EKU extension is absent, but server authentication is not possible because "+reason)
+		} else if len(cert.DNSNames) == 0 && len(cert.IPAddresses) == 0 && len(cert.EmailAddresses) > 0 {
+			return SMIMECertificate, "EKU extension is absent, and the certificate contains email addresses but no DNS names or IP addresses"
+		} else {
+			return ServerAuthenticationCertificate, "EKU extension is absent, so the certificate is usable for server authentication"
+		}
 	}
 
 	for _, eku := range cert.ExtKeyUsage {
 		switch eku {
 		case x509.ExtKeyUsageAny, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageMicrosoftServerGatedCrypto, x509.ExtKeyUsageNetscapeServerGatedCrypto:
-			return ServerAuthenticationCertificate
+			if permitted, reason := permitsEKU(x509.ExtKeyUsageServerAuth, nil); permitted {
+				if eku == x509.ExtKeyUsageAny {
+					return ServerAuthenticationCertificate, "EKU extension includes anyExtendedKeyUsage"
+				}
+				return ServerAuthenticationCertificate, "EKU extension includes a server authentication EKU"
+			} else {
+				reasons = append(reasons, "EKU extension includes a server authentication EKU, but "+reason)
+			}
+		}
+	}
+
+	if hasEKU(cert, x509.ExtKeyUsageAny, OIDEKUBrandIndicatorforMessageIdentification) {
+		if permitted, reason := permitsEKU(x509.ExtKeyUsageAny, OIDEKUBrandIndicatorforMessageIdentification); permitted {
+			return MarkCertificate, "EKU extension includes the Brand Indicator for Message Identification EKU"
+		} else {
+			reasons = append(reasons, "EKU extension includes the Brand Indicator for Message Identification EKU, but "+reason)
 		}
 	}
 
-	for _, unknownEKU := range cert.UnknownExtKeyUsage {
-		if unknownEKU.Equal(OIDEKUBrandIndicatorforMessageIdentification) {
-			return MarkCertificate
+	if hasEKU(cert, x509.ExtKeyUsageEmailProtection, nil) {
+		return SMIMECertificate, "EKU extension includes the emailProtection EKU"
+	} else if hasEKU(cert, x509.ExtKeyUsageCodeSigning, nil) {
+		return CodeSigningCertificate, "EKU extension includes the codeSigning EKU"
+	}
+
+	if len(reasons) > 0 {
+		return unknown, strings.Join(reasons, "; ")
+	}
+	return unknown, "EKU extension does not include any EKU associated with a CT Policy"
+}
+
+// hasEKU reports whether cert's EKU extension includes eku, or unknownEKU if it is not nil.
+func hasEKU(cert *x509.Certificate, eku x509.ExtKeyUsage, unknownEKU asn1.ObjectIdentifier) bool {
+	if unknownEKU != nil {
+		for _, oid := range cert.UnknownExtKeyUsage {
+			if oid.Equal(unknownEKU) {
+				return true
+			}
 		}
+		return false
 	}
 
-	return unknown
+	return slices.Contains(cert.ExtKeyUsage, eku)
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/crtsh/ctlint"
+
+	ctgo "github.com/google/certificate-transparency-go"
+)
+
+// runEntries lints the entries in saved responses from an RFC6962 log's get-entries endpoint.
+func runEntries(args []string) int {
+	flags := flag.NewFlagSet("entries", flag.ExitOnError)
+	start := flags.Int64("start", 0, "Log index of the first entry in the first file")
+	logListDir := flags.String("loglist-dir", "", logListDirUsage)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s entries [-start <index>] [-loglist-dir <directory>] <get-entries_response_filename>...\n", os.Args[0])
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		flags.Usage()
+		return -1
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	// Entries are numbered consecutively across the files, in the order they are specified.
+	index := *start
+	sctChecker := ctlint.NewLogEntrySCTChecker()
+	for _, filename := range flags.Args() {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return -1
+		}
+
+		var getEntriesResponse ctgo.GetEntriesResponse
+		if err = json.Unmarshal(data, &getEntriesResponse); err != nil {
+			fmt.Printf("Error: %s: %v\n", filename, err)
+			return -1
+		}
+
+		for i := range getEntriesResponse.Entries {
+			findings := ctlint.CheckLeafEntry(index, &getEntriesResponse.Entries[i])
+			if rawLogEntry, err := ctgo.RawLogEntryFromLeaf(index, &getEntriesResponse.Entries[i]); err == nil {
+				findings = append(findings, sctChecker.Check(rawLogEntry)...)
+			}
+			for _, finding := range findings {
+				fmt.Printf("[%d] %s\n", index, finding)
+			}
+			index++
+		}
+	}
+
+	return 0
+}
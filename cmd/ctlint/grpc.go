@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/crtsh/ctlint"
+	"github.com/crtsh/ctlint/ctlintpb"
+
+	"github.com/google/certificate-transparency-go/x509"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var policyGroups = map[ctlintpb.PolicyGroup]ctlint.CTPolicyGroup{
+	ctlintpb.PolicyGroup_POLICY_GROUP_SERVER_AUTHENTICATION: ctlint.ServerAuthenticationCertificate,
+	ctlintpb.PolicyGroup_POLICY_GROUP_MARK:                  ctlint.MarkCertificate,
+	ctlintpb.PolicyGroup_POLICY_GROUP_SMIME:                 ctlint.SMIMECertificate,
+	ctlintpb.PolicyGroup_POLICY_GROUP_CODE_SIGNING:          ctlint.CodeSigningCertificate,
+}
+
+var severities = map[string]ctlintpb.Severity{
+	"F": ctlintpb.Severity_SEVERITY_FATAL,
+	"E": ctlintpb.Severity_SEVERITY_ERROR,
+	"W": ctlintpb.Severity_SEVERITY_WARNING,
+	"N": ctlintpb.Severity_SEVERITY_NOTICE,
+	"I": ctlintpb.Severity_SEVERITY_INFO,
+}
+
+// grpcServer implements the CTLint gRPC service.
+type grpcServer struct {
+	ctlintpb.UnimplementedCTLintServer
+	issuerResolver ctlint.IssuerResolver
+}
+
+func newGRPCServer(issuerResolver ctlint.IssuerResolver) *grpc.Server {
+	server := grpc.NewServer()
+	ctlintpb.RegisterCTLintServer(server, &grpcServer{issuerResolver: issuerResolver})
+	return server
+}
+
+func (s *grpcServer) LintCertificate(ctx context.Context, req *ctlintpb.LintCertificateRequest) (*ctlintpb.LintResponse, error) {
+	return s.lintCertificate(req)
+}
+
+func (s *grpcServer) LintPrecertificate(ctx context.Context, req *ctlintpb.LintPrecertificateRequest) (*ctlintpb.LintResponse, error) {
+	return s.lintPrecertificate(req)
+}
+
+func (s *grpcServer) LintSCTs(ctx context.Context, req *ctlintpb.LintSCTsRequest) (*ctlintpb.LintResponse, error) {
+	return s.lintSCTs(req)
+}
+
+func (s *grpcServer) LintBatch(stream grpc.BidiStreamingServer[ctlintpb.LintBatchRequest, ctlintpb.LintBatchResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var resp *ctlintpb.LintResponse
+		switch request := req.Request.(type) {
+		case *ctlintpb.LintBatchRequest_Certificate:
+			resp, err = s.lintCertificate(request.Certificate)
+		case *ctlintpb.LintBatchRequest_Precertificate:
+			resp, err = s.lintPrecertificate(request.Precertificate)
+		case *ctlintpb.LintBatchRequest_Scts:
+			resp, err = s.lintSCTs(request.Scts)
+		default:
+			err = status.Error(codes.InvalidArgument, "request is empty")
+		}
+
+		batchResp := &ctlintpb.LintBatchResponse{Id: req.Id, Response: resp}
+		if err != nil {
+			batchResp.Error = status.Convert(err).Message()
+		}
+		if err = stream.Send(batchResp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *grpcServer) lintCertificate(req *ctlintpb.LintCertificateRequest) (*ctlintpb.LintResponse, error) {
+	cert, issuerCert, err := parseCertificates(req.Certificate, req.IssuerCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var findings []string
+	if issuerCert != nil {
+		findings = ctlint.CheckCertificateWithIssuer(cert, issuerCert, toCTPolicyGroups(req.PolicyGroup)...)
+	} else {
+		findings = ctlint.CheckCertificateWithIssuerResolver(cert, s.issuerResolver, toCTPolicyGroups(req.PolicyGroup)...)
+	}
+
+	return newLintResponse(cert, findings, start), nil
+}
+
+func (s *grpcServer) lintPrecertificate(req *ctlintpb.LintPrecertificateRequest) (*ctlintpb.LintResponse, error) {
+	precert, issuerCert, err := parseCertificates(req.Precertificate, req.IssuerCertificate)
+	if err != nil {
+		return nil, err
+	}
+	var caCert *x509.Certificate
+	if len(req.CaCertificate) > 0 {
+		if caCert, err = x509.ParseCertificate(req.CaCertificate); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "CA certificate could not be parsed: %v", err)
+		}
+	}
+
+	start := time.Now()
+	var findings []string
+	if issuerCert != nil {
+		findings = ctlint.CheckPrecertificateWithIssuer(precert, issuerCert, caCert, toCTPolicyGroups(req.PolicyGroup)...)
+	} else {
+		findings = ctlint.CheckPrecertificate(precert, toCTPolicyGroups(req.PolicyGroup)...)
+	}
+
+	return newLintResponse(precert, findings, start), nil
+}
+
+func (s *grpcServer) lintSCTs(req *ctlintpb.LintSCTsRequest) (*ctlintpb.LintResponse, error) {
+	precert, issuerCert, err := parseCertificates(req.Precertificate, req.IssuerCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var findings []string
+	if issuerCert != nil {
+		sha256IssuerSPKI := sha256.Sum256(issuerCert.RawSubjectPublicKeyInfo)
+		findings = ctlint.CheckSCTList(precert, &sha256IssuerSPKI, req.SctList, toCTPolicyGroups(req.PolicyGroup)...)
+	} else {
+		findings = ctlint.CheckSCTListWithIssuerResolver(precert, s.issuerResolver, req.SctList, toCTPolicyGroups(req.PolicyGroup)...)
+	}
+
+	return newLintResponse(precert, findings, start), nil
+}
+
+// parseCertificates parses a DER-encoded certificate and, if present, its DER-encoded issuer certificate.
+func parseCertificates(certDER, issuerCertDER []byte) (*x509.Certificate, *x509.Certificate, error) {
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "certificate could not be parsed: %v", err)
+	}
+
+	var issuerCert *x509.Certificate
+	if len(issuerCertDER) > 0 {
+		if issuerCert, err = x509.ParseCertificate(issuerCertDER); err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "issuer certificate could not be parsed: %v", err)
+		}
+	}
+
+	return cert, issuerCert, nil
+}
+
+func toCTPolicyGroups(policyGroup ctlintpb.PolicyGroup) []ctlint.CTPolicyGroup {
+	if ctPolicyGroup, found := policyGroups[policyGroup]; found {
+		return []ctlint.CTPolicyGroup{ctPolicyGroup}
+	}
+
+	return nil
+}
+
+// newLintResponse converts findings into structured findings, and records the metrics for linting cert.
+func newLintResponse(cert *x509.Certificate, findings []string, start time.Time) *ctlintpb.LintResponse {
+	recordLint("grpc", cert, findings, time.Since(start))
+
+	resp := &ctlintpb.LintResponse{}
+	for _, finding := range findings {
+		severity, message, _ := strings.Cut(finding, ": ")
+		resp.Findings = append(resp.Findings, &ctlintpb.Finding{Severity: severities[severity], Message: message})
+	}
+
+	return resp
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crtsh/ctlint"
+	"github.com/crtsh/ctlint/ctlintpb"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/tls"
+	"github.com/google/certificate-transparency-go/x509"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testIssuerResolver resolves every certificate's issuer to the same SPKI.
+type testIssuerResolver struct {
+	sha256IssuerSPKI [sha256.Size]byte
+}
+
+func (r testIssuerResolver) ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string) {
+	return &r.sha256IssuerSPKI, nil
+}
+
+// newTestPrecertificate issues a precertificate from a new self-signed CA, and returns the precertificate and the CA certificate.
+func newTestPrecertificate(t *testing.T) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test CA"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(365 * 24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: stdx509.KeyUsageCertSign}
+	caDER, err := stdx509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := stdx509.ParseCertificate(caDER)
+	template := &stdx509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		Subject:         pkix.Name{CommonName: "example.com"},
+		DNSNames:        []string{"example.com"},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(90 * 24 * time.Hour),
+		ExtKeyUsage:     []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier(x509.OIDExtensionCTPoison), Critical: true, Value: []byte{0x05, 0x00}}},
+	}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	precert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return precert, issuer
+}
+
+// newTestLog writes an Apple log list containing a new log to a temporary file, and returns the file's name and the log's private key.
+func newTestLog(t *testing.T) (string, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := stdx509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(spki)
+
+	usable := map[string]string{"timestamp": time.Now().Add(-365 * 24 * time.Hour).UTC().Format(time.RFC3339)}
+	data, err := json.Marshal(map[string]any{
+		"version":            "1.0",
+		"log_list_timestamp": time.Now().UTC().Format(time.RFC3339),
+		"operators": []any{map[string]any{
+			"name":  "Test Operator",
+			"email": []string{"ct@example.com"},
+			"logs": []any{map[string]any{
+				"description": "Test Operator 'Test' log",
+				"log_id":      logID[:],
+				"key":         spki,
+				"url":         "https://ct.example.com/",
+				"mmd":         86400,
+				"state":       map[string]any{"usable": usable},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "current_log_list.json")
+	if err = os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filename, key
+}
+
+// newTestSCTList returns a TLS-encoded SignedCertificateTimestampList containing an SCT for precert, signed by logKey.
+func newTestSCTList(t *testing.T, precert *x509.Certificate, sha256IssuerSPKI [sha256.Size]byte, logKey *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	spki, err := stdx509.MarshalPKIXPublicKey(&logKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tbsCert, err := x509.RemoveCTPoison(precert.RawTBSCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sct := ctgo.SignedCertificateTimestamp{SCTVersion: ctgo.V1, LogID: ctgo.LogID{KeyID: sha256.Sum256(spki)}, Timestamp: uint64(time.Now().Add(-time.Minute).UnixMilli())}
+	signatureInput, err := ctgo.SerializeSCTSignatureInput(sct, ctgo.LogEntry{Leaf: ctgo.MerkleTreeLeaf{
+		Version:  ctgo.V1,
+		LeafType: ctgo.TimestampedEntryLeafType,
+		TimestampedEntry: &ctgo.TimestampedEntry{
+			EntryType:    ctgo.PrecertLogEntryType,
+			Timestamp:    sct.Timestamp,
+			PrecertEntry: &ctgo.PreCert{IssuerKeyHash: sha256IssuerSPKI, TBSCertificate: tbsCert},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := tls.CreateSignature(*logKey, tls.SHA256, signatureInput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sct.Signature = ctgo.DigitallySigned(signature)
+
+	serializedSCT, err := tls.Marshal(sct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sctList, err := tls.Marshal(x509.SignedCertificateTimestampList{SCTList: []x509.SerializedSCT{{Val: serializedSCT}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sctList
+}
+
+func TestGRPCLintSCTs(t *testing.T) {
+	logListFilename, logKey := newTestLog(t)
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{AppleLogList: logListFilename}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ctlint.ReloadLogLists() })
+
+	precert, issuer := newTestPrecertificate(t)
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	sctList := newTestSCTList(t, precert, sha256IssuerSPKI, logKey)
+
+	// The issuer isn't in the CCADB data, so its SPKI can only be found by the server's issuer resolver.
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer(testIssuerResolver{sha256IssuerSPKI: sha256IssuerSPKI})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	lintClient := ctlintpb.NewCTLintClient(conn)
+
+	for _, test := range []struct {
+		name string
+		req  *ctlintpb.LintSCTsRequest
+	}{
+		{"issuer resolver", &ctlintpb.LintSCTsRequest{Precertificate: precert.Raw, SctList: sctList}},
+		{"issuer certificate", &ctlintpb.LintSCTsRequest{Precertificate: precert.Raw, IssuerCertificate: issuer.Raw, SctList: sctList}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := lintClient.LintSCTs(context.Background(), test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var validSignature bool
+			for _, finding := range resp.Findings {
+				switch finding.Message {
+				case "SCT has a valid signature":
+					validSignature = true
+				case "Cannot remove SCT List extension to derive TBSCertificate", "SCT has an invalid signature":
+					t.Errorf("unexpected finding: %s", finding.Message)
+				}
+			}
+			if !validSignature {
+				t.Errorf("SCT signature was not verified: %v", resp.Findings)
+			}
+		})
+	}
+
+	// The SCT list is still checked if it does not parse.
+	resp, err := lintClient.LintSCTs(context.Background(), &ctlintpb.LintSCTsRequest{Precertificate: precert.Raw, SctList: []byte{0x00}})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(resp.Findings) != 1 || resp.Findings[0].Message != "SCT list could not be parsed" {
+		t.Errorf("unexpected findings for an unparseable SCT list: %v", resp.Findings)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crtsh/ctlint"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// runImpact reports which of the certificates in the specified files and directories would no longer comply with each CT Policy if the specified logs were retired (or rejected).
+func runImpact(args []string) int {
+	flags := flag.NewFlagSet("impact", flag.ExitOnError)
+	var distrust ctlint.LogDistrust
+	flags.Func("log", "Log ID (base64 or hex) of a log to distrust; may be repeated", func(value string) error {
+		logID, err := parseLogID(value)
+		if err == nil {
+			distrust.LogIDs = append(distrust.LogIDs, logID)
+		}
+		return err
+	})
+	at := flags.String("at", "", "Time (RFC3339) at which the logs are distrusted (default: now)")
+	flags.BoolVar(&distrust.Rejected, "rejected", false, "Reject the logs, so that none of their SCTs count, instead of retiring them")
+	issuers := flags.String("issuers", "", "PEM bundle, DER file, or directory of issuer certificates, used to determine which root programs' CT Policies apply")
+	verbose := flags.Bool("v", false, "Report every finding, not only those for certificates that would lose compliance")
+	logListDir := flags.String("loglist-dir", "", logListDirUsage)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s impact -log <log_id> [-log <log_id>]... [-at <time>] [-rejected] [-issuers <issuer_bundle_or_directory>] [-v] [-loglist-dir <directory>] <cert_file_or_directory>...\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() < 1 || len(distrust.LogIDs) == 0 {
+		flags.Usage()
+		return -1
+	}
+
+	distrust.At = time.Now()
+	if *at != "" {
+		var err error
+		if distrust.At, err = time.Parse(time.RFC3339, *at); err != nil {
+			fmt.Printf("Error: -at: %v\n", err)
+			return -1
+		}
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	issuerResolver, err := newIssuerResolver(*issuers)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	exitCode := 0
+	nCerts, nAffectedCerts, nUnreadableFiles := 0, 0, 0
+	nAffectedCertsByPolicy := make(map[string]int)
+	for _, path := range flags.Args() {
+		filepath.WalkDir(path, func(filename string, entry fs.DirEntry, err error) error {
+			// A file or directory that cannot be read is reported, rather than abandoning the rest of the corpus.
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				nUnreadableFiles++
+				exitCode = -1
+				return nil
+			} else if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			certs, err := readCertificates(filename)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				nUnreadableFiles++
+				exitCode = -1
+				return nil
+			}
+			for i, cert := range certs {
+				name := filename
+				if len(certs) > 1 {
+					name = fmt.Sprintf("%s[%d]", filename, i)
+				}
+
+				sha256IssuerSPKI, _ := issuerResolver.ResolveIssuerSPKISHA256(cert)
+				lostPolicies, findings := ctlint.CheckLogDistrustImpact(cert, sha256IssuerSPKI, distrust)
+				nCerts++
+				if len(lostPolicies) > 0 {
+					nAffectedCerts++
+					for _, policy := range lostPolicies {
+						nAffectedCertsByPolicy[policy]++
+					}
+				}
+				for _, finding := range findings {
+					if *verbose || strings.HasPrefix(finding, "W: ") || strings.HasPrefix(finding, "E: ") {
+						fmt.Printf("%s: %s\n", name, finding)
+					}
+				}
+			}
+			return nil
+		})
+	}
+
+	fmt.Printf("%d of %d certificates would lose compliance (Chrome: %d, Apple: %d, Mozilla: %d)\n", nAffectedCerts, nCerts, nAffectedCertsByPolicy["Chrome"], nAffectedCertsByPolicy["Apple"], nAffectedCertsByPolicy["Mozilla"])
+	if nUnreadableFiles > 0 {
+		fmt.Printf("%d files could not be read\n", nUnreadableFiles)
+	}
+	return exitCode
+}
+
+// parseLogID parses a base64- or hex-encoded log ID.
+func parseLogID(value string) ([sha256.Size]byte, error) {
+	logID, err := hex.DecodeString(value)
+	if err != nil {
+		logID, err = base64.StdEncoding.DecodeString(value)
+	}
+	if err != nil || len(logID) != sha256.Size {
+		return [sha256.Size]byte{}, fmt.Errorf("%q is not a base64- or hex-encoded log ID", value)
+	}
+
+	return [sha256.Size]byte(logID), nil
+}
+
+// readCertificates reads the certificates in a PEM bundle or a DER file.
+func readCertificates(filename string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		cert, err := x509.ParseCertificate(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		return []*x509.Certificate{cert}, nil
+	}
+
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
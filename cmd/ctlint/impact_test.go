@@ -0,0 +1,34 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImpactContinuesAfterUnreadableFiles(t *testing.T) {
+	_, issuer := newTestPrecertificate(t)
+	dir := t.TempDir()
+	// Files are walked in lexical order, so the unparseable file precedes the certificate.
+	if err := os.WriteFile(filepath.Join(dir, "a.der"), []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filepath.Join(dir, "b.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: issuer.Raw}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var exitCode int
+	output := captureStdout(t, func() {
+		exitCode = runImpact([]string{"-log", base64.StdEncoding.EncodeToString(make([]byte, 32)), dir, filepath.Join(dir, "missing")})
+	})
+	if exitCode == 0 {
+		t.Error("impact succeeded despite unreadable files")
+	}
+	for _, want := range []string{"a.der", "missing", "0 of 1 certificates would lose compliance", "2 files could not be read"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/crtsh/ctlint"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// newIssuerResolver returns the IssuerResolver to use when no issuer certificate is specified.  If issuers (a PEM bundle, DER file, or directory) is specified, those issuer certificates are consulted before the CCADB data.
+func newIssuerResolver(issuers string) (ctlint.IssuerResolver, error) {
+	if issuers == "" {
+		return ctlint.CCADBIssuerResolver{}, nil
+	}
+
+	issuerStore := ctlint.NewIssuerStore()
+	fileInfo, err := os.Stat(issuers)
+	if err == nil {
+		if fileInfo.IsDir() {
+			err = issuerStore.LoadDirectory(issuers)
+		} else {
+			err = issuerStore.LoadFile(issuers)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ctlint.IssuerResolvers{issuerStore, ctlint.SubjectDNIssuerResolver{Store: issuerStore}, ctlint.CCADBIssuerResolver{}}, nil
+}
+
+// lintDER runs the applicable checks on a DER-encoded certificate, precertificate, RFC9162 CMS precertificate, or (if tbs is true) unsigned TBSCertificate.  The parsed certificate is also returned, except for RFC9162 CMS precertificates.
+func lintDER(der []byte, issuerCert *x509.Certificate, issuerResolver ctlint.IssuerResolver, tbs bool) (*x509.Certificate, []string, error) {
+	if tbs {
+		tbsCert, err := ctlint.ParseTBSCertificate(der)
+		if err != nil {
+			return nil, nil, err
+		} else if tbsCert.IsPrecertificate() {
+			return tbsCert, ctlint.CheckTBSPrecertificate(der), nil
+		} else if issuerCert != nil {
+			sha256IssuerSPKI := sha256.Sum256(issuerCert.RawSubjectPublicKeyInfo)
+			return tbsCert, ctlint.CheckTBSCertificate(der, &sha256IssuerSPKI), nil
+		}
+		return tbsCert, ctlint.CheckTBSCertificateWithIssuerResolver(der, issuerResolver), nil
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil && ctlint.IsV2Precertificate(der) {
+		return nil, ctlint.CheckV2Precertificate(der, issuerCert), nil
+	} else if err != nil {
+		return nil, nil, err
+	} else if cert.IsPrecertificate() && issuerCert != nil {
+		return cert, ctlint.CheckPrecertificateWithIssuer(cert, issuerCert, nil), nil
+	} else if cert.IsPrecertificate() {
+		return cert, ctlint.CheckPrecertificate(cert), nil
+	} else if issuerCert != nil && cert.IsCA && slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCertificateTransparency) {
+		return cert, ctlint.CheckPrecertificateSigningCertificate(cert, issuerCert), nil
+	} else if issuerCert != nil {
+		return cert, ctlint.CheckCertificateWithIssuer(cert, issuerCert), nil
+	}
+
+	return cert, ctlint.CheckCertificateWithIssuerResolver(cert, issuerResolver), nil
+}
+
+const logListDirUsage = "Directory of log lists saved by \"ctlint update-loglists\", which are preferred over the embedded log lists"
+
+// reloadLogListsOnSIGHUP reloads the log lists (preferring logListFiles, if specified) whenever the process receives SIGHUP, so that a long-running process can switch to updated log lists without restarting.  Lints that are already in progress continue to use the log lists that they started with.
+func reloadLogListsOnSIGHUP(logListFiles ctlint.LogListFiles) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			if snapshot, err := ctlint.ReloadLogLists(logListFiles); err != nil {
+				fmt.Printf("Error: Could not reload log lists: %v\n", err)
+			} else {
+				fmt.Printf("Reloaded log lists (snapshot version %d)\n", snapshot.Version())
+			}
+		}
+	}()
+}
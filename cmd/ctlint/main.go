@@ -2,42 +2,80 @@ package main
 
 import (
 	"crypto/sha256"
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/crtsh/ctlint"
 
-	"github.com/crtsh/ctloglists"
 	"github.com/google/certificate-transparency-go/x509"
 )
 
+// subcommands maps each subcommand name to the function that runs it with the remaining arguments and returns the exit code.
+var subcommands = map[string]func(args []string) int{
+	"entries":         runEntries,
+	"impact":          runImpact,
+	"monitor":         runMonitor,
+	"serve":           runServe,
+	"tiles":           runTiles,
+	"update-loglists": runUpdateLogLists,
+}
+
 func main() {
 	exitCode := -1
 	defer func() { os.Exit(int(exitCode)) }()
 
-	if len(os.Args) < 2 || len(os.Args) > 3 {
-		fmt.Printf("Usage: %s <cert_filename> [<issuer_cert_filename>]\n", os.Args[0])
+	if len(os.Args) > 1 {
+		if subcommand, found := subcommands[os.Args[1]]; found {
+			exitCode = subcommand(os.Args[2:])
+			return
+		}
+	}
+
+	issuers := flag.String("issuers", "", "PEM bundle, DER file, or directory of issuer certificates to consult when no issuer certificate is specified")
+	logListDir := flag.String("loglist-dir", "", logListDirUsage)
+	firefoxKnownLogs := flag.String("firefox-known-logs", "", "Evaluate the Mozilla CT Policy using the log list in this CTKnownLogs.h from a particular Firefox release")
+	appleLogList := flag.String("apple-log-list", "", "Evaluate the Apple CT Policy using the log list in this current_log_list.json")
+	tbs := flag.Bool("tbs", false, "The certificate file contains an unsigned DER-encoded TBSCertificate")
+	precertFilename := flag.String("precert", "", "DER-encoded precertificate that was logged for a Mark Certificate, to check that the Subject and the Mark Representation match")
+	timeline := flag.Bool("timeline", false, "Instead of linting the certificate, report when (if ever) it would stop complying with each CT Policy, due to scheduled log state transitions before it expires")
+	flag.Usage = func() {
+		fmt.Printf("Usage: %s [-issuers <issuer_bundle_or_directory>] [-loglist-dir <directory>] [-firefox-known-logs <CTKnownLogs.h>] [-apple-log-list <current_log_list.json>] [-precert <precert_filename>] [-tbs | -timeline] <cert_filename> [<issuer_cert_filename>]\n", os.Args[0])
+		fmt.Printf("       %s entries [-start <index>] [-loglist-dir <directory>] <get-entries_response_filename>...\n", os.Args[0])
+		fmt.Printf("       %s tiles [-start <index>] [-loglist-dir <directory>] <log_directory>\n", os.Args[0])
+		fmt.Printf("       %s monitor [-tiled] [-position <filename>] [-interval <duration>] [-batch <size>] [-once] [-metrics-listen <address>] [-loglist-dir <directory>] <log_url>\n", os.Args[0])
+		fmt.Printf("       %s serve [-listen <address>] [-grpc-listen <address>] [-issuers <issuer_bundle_or_directory>] [-loglist-dir <directory>]\n", os.Args[0])
+		fmt.Printf("       %s impact -log <log_id> [-log <log_id>]... [-at <time>] [-rejected] [-issuers <issuer_bundle_or_directory>] [-v] [-loglist-dir <directory>] <cert_file_or_directory>...\n", os.Args[0])
+		fmt.Printf("       %s update-loglists [-dir <directory>] [-<list>-url <url>]...\n", os.Args[0])
+	}
+	flag.Parse()
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		flag.Usage()
+		return
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir, FirefoxKnownLogs: *firefoxKnownLogs, AppleLogList: *appleLogList}); err != nil {
+		fmt.Printf("Error: %v\n", err)
 		return
 	}
 
-	var err error
-	if err = ctloglists.LoadLogLists(); err != nil {
+	issuerResolver, err := newIssuerResolver(*issuers)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
 
 	var infile []byte
-	infile, err = os.ReadFile(os.Args[1])
+	infile, err = os.ReadFile(flag.Arg(0))
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
 	}
 
 	var issuerCert *x509.Certificate
-	var sha256IssuerSPKI *[sha256.Size]byte
-	if len(os.Args) == 3 {
+	if flag.NArg() == 2 {
 		var issuercertfile []byte
-		issuercertfile, err = os.ReadFile(os.Args[2])
+		issuercertfile, err = os.ReadFile(flag.Arg(1))
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			return
@@ -46,19 +84,23 @@ func main() {
 			fmt.Printf("Error: %v\n", err)
 			return
 		}
-		spkiSHA256 := sha256.Sum256(issuerCert.RawSubjectPublicKeyInfo)
-		sha256IssuerSPKI = &spkiSHA256
 	}
 
 	var findings []string
-	cert, err := x509.ParseCertificate(infile)
+	if *timeline {
+		findings, err = complianceTimeline(infile, issuerCert, issuerResolver)
+	} else {
+		var cert *x509.Certificate
+		if cert, findings, err = lintDER(infile, issuerCert, issuerResolver, *tbs); err == nil && *precertFilename != "" {
+			var precertFindings []string
+			if precertFindings, err = checkMarkCertificatePrecertificate(cert, *precertFilename); err == nil {
+				findings = append(findings, precertFindings...)
+			}
+		}
+	}
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		return
-	} else if cert.IsPrecertificate() {
-		findings = ctlint.CheckPrecertificate(cert)
-	} else {
-		findings = ctlint.CheckCertificate(cert, sha256IssuerSPKI)
 	}
 
 	for _, finding := range findings {
@@ -67,3 +109,43 @@ func main() {
 
 	exitCode = 0
 }
+
+// checkMarkCertificatePrecertificate checks that a Mark Certificate matches the DER-encoded precertificate in precertFilename.
+func checkMarkCertificatePrecertificate(cert *x509.Certificate, precertFilename string) ([]string, error) {
+	if cert == nil || cert.IsPrecertificate() {
+		return nil, fmt.Errorf("-precert requires the certificate file to contain a certificate")
+	} else if policyGroup, _ := ctlint.DetectPolicyGroup(cert); policyGroup != ctlint.MarkCertificate {
+		return nil, fmt.Errorf("-precert requires a Mark Certificate, but this is a %s", policyGroup)
+	}
+
+	der, err := os.ReadFile(precertFilename)
+	if err != nil {
+		return nil, err
+	}
+	precert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	} else if !precert.IsPrecertificate() {
+		return nil, fmt.Errorf("%s does not contain a precertificate", precertFilename)
+	}
+
+	return ctlint.CheckMarkCertificatePrecertificateConsistency(cert, precert), nil
+}
+
+// complianceTimeline reports when (if ever) a DER-encoded certificate would stop complying with each CT Policy.  The issuer, which determines which CT Policies apply, is identified by issuerCert if specified, or else resolved using issuerResolver.
+func complianceTimeline(der []byte, issuerCert *x509.Certificate, issuerResolver ctlint.IssuerResolver) ([]string, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var sha256IssuerSPKI *[sha256.Size]byte
+	if issuerCert != nil {
+		spkiHash := sha256.Sum256(issuerCert.RawSubjectPublicKeyInfo)
+		sha256IssuerSPKI = &spkiHash
+	} else {
+		sha256IssuerSPKI, _ = issuerResolver.ResolveIssuerSPKISHA256(cert)
+	}
+
+	return ctlint.CheckComplianceTimeline(cert, sha256IssuerSPKI), nil
+}
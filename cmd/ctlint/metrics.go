@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crtsh/ctlint"
+
+	"github.com/google/certificate-transparency-go/x509"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	certificatesLinted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ctlint_certificates_linted_total",
+		Help: "Number of certificates and precertificates linted, by CT policy group.",
+	}, []string{"policy_group"})
+	findingsReported = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ctlint_findings_total",
+		Help: "Number of findings reported, by lint and severity.",
+	}, []string{"lint", "severity"})
+	sctVerificationFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ctlint_sct_verification_failures_total",
+		Help: "Number of SCTs with invalid signatures, by the log that they purport to be from.",
+	}, []string{"log"})
+	lintDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ctlint_lint_duration_seconds",
+		Help:    "Time taken to lint a certificate, precertificate or log entry.",
+		Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"source"})
+)
+
+// Like the 70-day check in the Chrome and Mozilla CT Policies, the age of each log list is measured from its log_list_timestamp.
+func init() {
+	for _, name := range []string{"Chrome", "Apple", "Mozilla", "BIMI", "crt.sh"} {
+		promauto.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "ctlint_log_list_age_seconds",
+			Help:        "Time since the log_list_timestamp of each log list.",
+			ConstLabels: prometheus.Labels{"list": name},
+		}, func() float64 {
+			logList := ctlint.CurrentLogListSnapshot().LogLists()[name]
+			if logList == nil {
+				return math.NaN()
+			}
+			return time.Since(logList.LogListTimestamp).Seconds()
+		})
+	}
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "ctlint_log_list_snapshot_version",
+		Help: "Version of the log list snapshot that new lints use, which increases each time the log lists are reloaded.",
+	}, func() float64 {
+		return float64(ctlint.CurrentLogListSnapshot().Version())
+	})
+}
+
+// metricsHandler serves the metrics in the Prometheus exposition format.
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+var findingSeverities = map[string]string{"F": "fatal", "E": "error", "W": "warning", "N": "notice", "I": "info"}
+
+// recordLint updates the metrics for one certificate, precertificate or log entry, which was linted by source and produced findings.
+func recordLint(source string, cert *x509.Certificate, findings []string, duration time.Duration) {
+	lintDuration.WithLabelValues(source).Observe(duration.Seconds())
+
+	policyGroup := "Unknown"
+	if cert != nil {
+		group, _ := ctlint.DetectPolicyGroup(cert)
+		policyGroup = group.String()
+	}
+	certificatesLinted.WithLabelValues(policyGroup).Inc()
+
+	for _, finding := range findings {
+		severity, message, found := strings.Cut(finding, ": ")
+		if !found {
+			continue
+		} else if severity, found = findingSeverities[severity]; !found {
+			continue
+		}
+
+		// Each lint has a stable code, so the lint label doesn't depend on the wording of findings or on the variable text that they include.
+		lintCode := ctlint.LintCode(finding)
+		findingsReported.WithLabelValues(lintCode, severity).Inc()
+
+		if lintCode == "sct_invalid_signature" || lintCode == "v2_sct_invalid_signature" {
+			sctVerificationFailures.WithLabelValues(invalidSignatureLogLabel(message)).Inc()
+		}
+	}
+}
+
+// invalidSignatureLogLabel returns the description of the log that an SCT with an invalid signature purports to be from.  Only the descriptions of logs in the current log list snapshot are used, so that the number of label values is bounded.
+func invalidSignatureLogLabel(message string) string {
+	_, description, found := strings.Cut(message, " purporting to be from ")
+	if !found {
+		return "Unknown log"
+	}
+	if strings.HasPrefix(message, "RFC9162 ") {
+		// RFC9162 findings append the log ID to the description.
+		if i := strings.LastIndex(description, " ("); i >= 0 {
+			description = description[:i]
+		}
+	}
+
+	if !ctlint.CurrentLogListSnapshot().IsKnownLogDescription(description) {
+		return "Unknown log"
+	}
+	return description
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"iter"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/crtsh/ctlint"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/client"
+	"github.com/google/certificate-transparency-go/jsonclient"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// The position file is rewritten after every savePositionInterval entries, so that little work is repeated if the monitor is interrupted.
+const savePositionInterval = 1024
+
+// monitoredLog is a CT log that is being followed by the monitor.
+type monitoredLog interface {
+	// treeSize returns the current number of entries in the log.
+	treeSize(ctx context.Context) (int64, error)
+	// entries iterates over the log's entries from index start up to (but not including) index end, with the findings for each entry.
+	entries(ctx context.Context, start, end int64) iter.Seq2[*lintedEntry, error]
+}
+
+type lintedEntry struct {
+	index    int64
+	cert     *x509.Certificate
+	issuer   string
+	findings []string
+	duration time.Duration
+}
+
+// issuerSummary counts the entries, and the findings of each severity, for one issuer.
+type issuerSummary struct {
+	entries  int
+	findings map[string]int
+}
+
+// runMonitor follows an RFC6962 or static-ct-api log from a saved position, lints every new entry, and persists its position.
+func runMonitor(args []string) int {
+	flags := flag.NewFlagSet("monitor", flag.ExitOnError)
+	tiled := flags.Bool("tiled", false, "The log URL is the monitoring prefix of a static-ct-api log")
+	positionFilename := flags.String("position", "", "File in which the index of the next entry to lint is persisted (default: start at index 0, and don't persist)")
+	interval := flags.Duration("interval", time.Minute, "How often to poll the log for new entries")
+	batchSize := flags.Int64("batch", 256, "Maximum number of entries to request from an RFC6962 log at once")
+	once := flags.Bool("once", false, "Exit after linting all of the log's current entries, instead of polling for new entries")
+	metricsListen := flags.String("metrics-listen", "", "Address on which to serve the /metrics endpoint (default: don't serve metrics)")
+	logListDir := flags.String("loglist-dir", "", logListDirUsage)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s monitor [-tiled] [-position <filename>] [-interval <duration>] [-batch <size>] [-once] [-metrics-listen <address>] [-loglist-dir <directory>] <log_url>\n", os.Args[0])
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return -1
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	reloadLogListsOnSIGHUP(ctlint.LogListFiles{Dir: *logListDir})
+
+	position, err := readPosition(*positionFilename)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	var log monitoredLog
+	if *tiled {
+		var tiledLog *ctlint.TiledLog
+		if tiledLog, err = ctlint.OpenTiledLogURL(flags.Arg(0), httpClient); err == nil {
+			log = &monitoredTiledLog{tiledLog: tiledLog, sctChecker: ctlint.NewLogEntrySCTChecker()}
+		}
+	} else {
+		var logClient *client.LogClient
+		if logClient, err = client.New(flags.Arg(0), httpClient, jsonclient.Options{UserAgent: "ctlint"}); err == nil {
+			log = &monitoredRFC6962Log{logClient: logClient, batchSize: *batchSize, sctChecker: ctlint.NewLogEntrySCTChecker()}
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	if *metricsListen != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", metricsHandler())
+		go func() {
+			if err := newHTTPServer(*metricsListen, mux).ListenAndServe(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		summaries := make(map[string]*issuerSummary)
+		treeSize, err := log.treeSize(ctx)
+		if err == nil {
+			for entry, entryErr := range log.entries(ctx, position, treeSize) {
+				if entryErr != nil {
+					err = entryErr
+					break
+				}
+
+				summary, found := summaries[entry.issuer]
+				if !found {
+					summary = &issuerSummary{findings: make(map[string]int)}
+					summaries[entry.issuer] = summary
+				}
+				summary.entries++
+				recordLint("monitor", entry.cert, entry.findings, entry.duration)
+				for _, finding := range entry.findings {
+					fmt.Printf("[%d] %s: %s\n", entry.index, entry.issuer, finding)
+					summary.findings[finding[:1]]++
+				}
+
+				if position = entry.index + 1; position%savePositionInterval == 0 {
+					if err = writePosition(*positionFilename, position); err != nil {
+						break
+					}
+				}
+			}
+		}
+
+		printIssuerSummaries(summaries)
+		if writeErr := writePosition(*positionFilename, position); err == nil {
+			err = writeErr
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Printf("Error: %v\n", err)
+			if *once {
+				return -1
+			}
+		}
+
+		if *once {
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// readPosition reads the index of the next entry to lint from the position file, if it exists.
+func readPosition(filename string) (int64, error) {
+	if filename == "" {
+		return 0, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	position, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || position < 0 {
+		return 0, fmt.Errorf("%s does not contain a valid log position", filename)
+	}
+
+	return position, nil
+}
+
+// writePosition atomically replaces the contents of the position file.
+func writePosition(filename string, position int64) error {
+	if filename == "" {
+		return nil
+	}
+
+	if err := os.WriteFile(filename+".tmp", []byte(strconv.FormatInt(position, 10)+"\n"), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(filename+".tmp", filename)
+}
+
+func printIssuerSummaries(summaries map[string]*issuerSummary) {
+	var issuers []string
+	for issuer := range summaries {
+		issuers = append(issuers, issuer)
+	}
+	slices.Sort(issuers)
+
+	for _, issuer := range issuers {
+		summary := summaries[issuer]
+		fmt.Printf("Summary: %s: %d entries, %d fatal errors, %d errors, %d warnings, %d notices\n", issuer, summary.entries, summary.findings["F"], summary.findings["E"], summary.findings["W"], summary.findings["N"])
+	}
+}
+
+// newLintedEntry lints a log entry, and identifies its issuer by the Subject DN of the first certificate in the entry's chain, or else by the Issuer DN of the entry's certificate or precertificate.
+func newLintedEntry(index int64, rawLogEntry *ctgo.RawLogEntry, check func() []string) *lintedEntry {
+	entry := &lintedEntry{index: index, issuer: "Unknown issuer"}
+	if rawLogEntry != nil {
+		if cert, err := x509.ParseCertificate(rawLogEntry.Cert.Data); !x509.IsFatal(err) {
+			entry.cert, entry.issuer = cert, cert.Issuer.String()
+		}
+		if len(rawLogEntry.Chain) > 0 {
+			if issuer, err := x509.ParseCertificate(rawLogEntry.Chain[0].Data); !x509.IsFatal(err) {
+				entry.issuer = issuer.Subject.String()
+			}
+		}
+	}
+
+	start := time.Now()
+	entry.findings = check()
+	entry.duration = time.Since(start)
+	return entry
+}
+
+type monitoredRFC6962Log struct {
+	logClient  *client.LogClient
+	batchSize  int64
+	sctChecker *ctlint.LogEntrySCTChecker
+}
+
+func (l *monitoredRFC6962Log) treeSize(ctx context.Context) (int64, error) {
+	sth, err := l.logClient.GetSTH(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(sth.TreeSize), nil
+}
+
+func (l *monitoredRFC6962Log) entries(ctx context.Context, start, end int64) iter.Seq2[*lintedEntry, error] {
+	return func(yield func(*lintedEntry, error) bool) {
+		for start < end {
+			getEntriesResponse, err := l.logClient.GetRawEntries(ctx, start, min(start+l.batchSize, end)-1)
+			if err == nil && len(getEntriesResponse.Entries) == 0 {
+				err = fmt.Errorf("get-entries returned no entries from index %d", start)
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			// Logs may return fewer entries than were requested.
+			for i := range getEntriesResponse.Entries {
+				var check func() []string
+				rawLogEntry, decodeErr := ctgo.RawLogEntryFromLeaf(start, &getEntriesResponse.Entries[i])
+				if decodeErr != nil {
+					// The entry is still reported (attributed to an unknown issuer), with a finding that explains why it could not be decoded.
+					leafEntry := &getEntriesResponse.Entries[i]
+					check = func() []string { return ctlint.CheckLeafEntry(start, leafEntry) }
+				} else {
+					check = func() []string { return append(ctlint.CheckLogEntry(rawLogEntry), l.sctChecker.Check(rawLogEntry)...) }
+				}
+				if !yield(newLintedEntry(start, rawLogEntry, check), nil) {
+					return
+				} else if err = ctx.Err(); err != nil {
+					yield(nil, err)
+					return
+				}
+				start++
+			}
+		}
+	}
+}
+
+type monitoredTiledLog struct {
+	tiledLog   *ctlint.TiledLog
+	sctChecker *ctlint.LogEntrySCTChecker
+}
+
+func (l *monitoredTiledLog) treeSize(ctx context.Context) (int64, error) {
+	if err := l.tiledLog.ReadCheckpoint(); err != nil {
+		return 0, err
+	}
+
+	return l.tiledLog.TreeSize(), nil
+}
+
+func (l *monitoredTiledLog) entries(ctx context.Context, start, end int64) iter.Seq2[*lintedEntry, error] {
+	return func(yield func(*lintedEntry, error) bool) {
+		for rawLogEntry, err := range l.tiledLog.Entries(start) {
+			if err != nil {
+				yield(nil, err)
+				return
+			} else if rawLogEntry.Index >= end {
+				return
+			}
+
+			check := func() []string {
+				return append(ctlint.CheckTiledLogEntry(rawLogEntry), l.sctChecker.Check(rawLogEntry)...)
+			}
+			if !yield(newLintedEntry(rawLogEntry.Index, rawLogEntry, check), nil) {
+				return
+			} else if err = ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/tls"
+)
+
+// testRFC6962Log is a local stand-in for an RFC6962 log, which serves get-sth and get-entries from a list of entries that can grow.
+type testRFC6962Log struct {
+	mu           sync.Mutex
+	entries      []ctgo.LeafEntry
+	entriesStart []int64 // The start parameter of each get-entries request.
+}
+
+func (l *testRFC6962Log) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/ct/v1/get-sth":
+		json.NewEncoder(w).Encode(map[string]any{
+			"tree_size":           len(l.entries),
+			"timestamp":           time.Now().UnixMilli(),
+			"sha256_root_hash":    make([]byte, 32),
+			"tree_head_signature": []byte{0x04, 0x03, 0x00, 0x00},
+		})
+	case "/ct/v1/get-entries":
+		start, err1 := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		end, err2 := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+		if err1 != nil || err2 != nil || start < 0 || start > end || start >= int64(len(l.entries)) {
+			http.Error(w, "bad range", http.StatusBadRequest)
+			return
+		}
+		l.entriesStart = append(l.entriesStart, start)
+		json.NewEncoder(w).Encode(ctgo.GetEntriesResponse{Entries: l.entries[start:min(end+1, int64(len(l.entries)))]})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (l *testRFC6962Log) add(entries ...ctgo.LeafEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entries...)
+}
+
+// newTestX509LeafEntry returns a get-entries entry containing a new certificate, issued by a new CA.
+func newTestX509LeafEntry(t *testing.T, commonName string) ctgo.LeafEntry {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: commonName + " CA"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(24 * time.Hour), IsCA: true, BasicConstraintsValid: true}
+	caDER, err := stdx509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := stdx509.ParseCertificate(caDER)
+	template := &stdx509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: commonName}, DNSNames: []string{commonName}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(24 * time.Hour), ExtKeyUsage: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth}}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafInput, err := tls.Marshal(ctgo.MerkleTreeLeaf{Version: ctgo.V1, LeafType: ctgo.TimestampedEntryLeafType, TimestampedEntry: &ctgo.TimestampedEntry{
+		Timestamp: uint64(time.Now().UnixMilli()),
+		EntryType: ctgo.X509LogEntryType,
+		X509Entry: &ctgo.ASN1Cert{Data: der},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extraData, err := tls.Marshal(ctgo.CertificateChain{Entries: []ctgo.ASN1Cert{{Data: caDER}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctgo.LeafEntry{LeafInput: leafInput, ExtraData: extraData}
+}
+
+// captureStdout returns what f writes to os.Stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	defer func() { os.Stdout = stdout }()
+	f()
+	w.Close()
+	return <-output
+}
+
+func TestMonitorPersistsPosition(t *testing.T) {
+	testLog := &testRFC6962Log{}
+	testLog.add(newTestX509LeafEntry(t, "a.example.com"), ctgo.LeafEntry{LeafInput: []byte{0x00}}, newTestX509LeafEntry(t, "b.example.com"))
+	server := httptest.NewServer(testLog)
+	defer server.Close()
+
+	positionFilename := filepath.Join(t.TempDir(), "position")
+	monitor := func() (int, string) {
+		var exitCode int
+		output := captureStdout(t, func() {
+			exitCode = runMonitor([]string{"-once", "-batch", "2", "-position", positionFilename, server.URL})
+		})
+		return exitCode, output
+	}
+	readPositionFile := func() string {
+		data, err := os.ReadFile(positionFilename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	exitCode, output := monitor()
+	if exitCode != 0 {
+		t.Fatalf("monitor exited with %d:\n%s", exitCode, output)
+	} else if position := readPositionFile(); position != "3" {
+		t.Errorf("position = %s, want 3", position)
+	} else if !strings.Contains(output, "[1] Unknown issuer: E: Log entry could not be decoded") {
+		t.Errorf("undecodable entry was not reported:\n%s", output)
+	} else if !strings.Contains(output, "CN=a.example.com CA") || !strings.Contains(output, "CN=b.example.com CA") {
+		t.Errorf("entries were not attributed to their issuers:\n%s", output)
+	}
+
+	// When the log grows, only the new entries are linted.
+	testLog.add(newTestX509LeafEntry(t, "c.example.com"), newTestX509LeafEntry(t, "d.example.com"))
+	testLog.entriesStart = nil
+	exitCode, output = monitor()
+	if exitCode != 0 {
+		t.Fatalf("monitor exited with %d:\n%s", exitCode, output)
+	} else if position := readPositionFile(); position != "5" {
+		t.Errorf("position = %s, want 5", position)
+	} else if len(testLog.entriesStart) == 0 || testLog.entriesStart[0] != 3 {
+		t.Errorf("get-entries was requested from %v, want 3", testLog.entriesStart)
+	} else if strings.Contains(output, "a.example.com") {
+		t.Errorf("previously linted entries were linted again:\n%s", output)
+	}
+
+	// When the log has not grown, no entries are requested.
+	testLog.entriesStart = nil
+	if exitCode, output = monitor(); exitCode != 0 {
+		t.Fatalf("monitor exited with %d:\n%s", exitCode, output)
+	} else if len(testLog.entriesStart) != 0 {
+		t.Errorf("get-entries was requested from %v, although the log has not grown", testLog.entriesStart)
+	} else if position := readPositionFile(); position != "5" {
+		t.Errorf("position = %s, want 5", position)
+	}
+}
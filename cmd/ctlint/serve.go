@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/crtsh/ctlint"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// Certificates larger than this are rejected.
+const maxRequestBodySize = 1 << 20
+
+type lintResponse struct {
+	Findings []string `json:"findings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// runServe runs ctlint as a long-running linting service.
+func runServe(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := flags.String("listen", ":8080", "Address on which to serve the /lint and /metrics endpoints")
+	grpcListen := flags.String("grpc-listen", "", "Address on which to serve the CTLint gRPC service (default: don't serve gRPC)")
+	issuers := flags.String("issuers", "", "PEM bundle, DER file, or directory of issuer certificates to consult when no issuer certificate is specified")
+	logListDir := flags.String("loglist-dir", "", logListDirUsage)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s serve [-listen <address>] [-grpc-listen <address>] [-issuers <issuer_bundle_or_directory>] [-loglist-dir <directory>]\n", os.Args[0])
+	}
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return -1
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	reloadLogListsOnSIGHUP(ctlint.LogListFiles{Dir: *logListDir})
+
+	issuerResolver, err := newIssuerResolver(*issuers)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	if *grpcListen != "" {
+		listener, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return -1
+		}
+		go func() {
+			if err := newGRPCServer(issuerResolver).Serve(listener); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+		}()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /lint", lintHandler(issuerResolver))
+	mux.Handle("GET /metrics", metricsHandler())
+	if err = newHTTPServer(*listen, mux).ListenAndServe(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	return -1
+}
+
+// newHTTPServer returns an HTTP server with timeouts, so that slow or idle clients cannot hold connections open indefinitely.
+func newHTTPServer(addr string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      2 * time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}
+}
+
+// lintHandler lints the certificate, precertificate, RFC9162 CMS precertificate, or (if the "tbs" query parameter is "true") unsigned TBSCertificate in the request body, which may be DER or PEM.  If the body is PEM, a second PEM block may contain the issuer certificate.
+func lintHandler(issuerResolver ctlint.IssuerResolver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		der, issuerCert, err := readLintRequest(w, r)
+		var cert *x509.Certificate
+		var findings []string
+		if err == nil {
+			start := time.Now()
+			if cert, findings, err = lintDER(der, issuerCert, issuerResolver, r.URL.Query().Get("tbs") == "true"); err == nil {
+				recordLint("serve", cert, findings, time.Since(start))
+			}
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(lintResponse{Error: err.Error()})
+			return
+		}
+
+		json.NewEncoder(w).Encode(lintResponse{Findings: findings})
+	})
+}
+
+func readLintRequest(w http.ResponseWriter, r *http.Request) ([]byte, *x509.Certificate, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if err != nil {
+		return nil, nil, err
+	} else if len(body) == 0 {
+		return nil, nil, errors.New("request body is empty")
+	}
+
+	block, rest := pem.Decode(body)
+	if block == nil {
+		return body, nil, nil
+	}
+
+	var issuerCert *x509.Certificate
+	if issuerBlock, _ := pem.Decode(rest); issuerBlock != nil {
+		if issuerCert, err = x509.ParseCertificate(issuerBlock.Bytes); err != nil {
+			return nil, nil, fmt.Errorf("issuer certificate could not be parsed: %v", err)
+		}
+	}
+
+	return block.Bytes, issuerCert, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/crtsh/ctlint"
+)
+
+// runTiles lints the entries of a static-ct-api log whose checkpoint, data tiles and issuers have been saved to a local directory.
+func runTiles(args []string) int {
+	flags := flag.NewFlagSet("tiles", flag.ExitOnError)
+	start := flags.Int64("start", 0, "Log index of the first entry to lint")
+	logListDir := flags.String("loglist-dir", "", logListDirUsage)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s tiles [-start <index>] [-loglist-dir <directory>] <log_directory>\n", os.Args[0])
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return -1
+	}
+
+	if _, err := ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: *logListDir}); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	tiledLog, err := ctlint.OpenTiledLog(flags.Arg(0))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return -1
+	}
+
+	sctChecker := ctlint.NewLogEntrySCTChecker()
+	for rawLogEntry, err := range tiledLog.Entries(*start) {
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return -1
+		}
+		for _, finding := range append(ctlint.CheckTiledLogEntry(rawLogEntry), sctChecker.Check(rawLogEntry)...) {
+			fmt.Printf("[%d] %s\n", rawLogEntry.Index, finding)
+		}
+	}
+
+	return 0
+}
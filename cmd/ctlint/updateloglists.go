@@ -0,0 +1,163 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crtsh/ctlint"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+)
+
+const (
+	appleLogListURL     = "https://valid.apple.com/ct/log_list/current_log_list.json"
+	mozillaKnownLogsURL = "https://hg.mozilla.org/mozilla-central/raw-file/tip/security/ct/CTKnownLogs.h"
+)
+
+// Log lists larger than this are rejected.
+const maxLogListSize = 16 << 20
+
+// runUpdateLogLists downloads the current Chrome, Apple, Mozilla and (optionally) BIMI log lists into a log list directory, which the other commands use instead of the embedded log lists when -loglist-dir is specified.
+func runUpdateLogLists(args []string) int {
+	flags := flag.NewFlagSet("update-loglists", flag.ExitOnError)
+	dir := flags.String("dir", defaultLogListDir(), "Directory in which to save the log lists")
+	chromeURL := flags.String("chrome-url", loglist3.AllLogListURL, "URL of the Chrome log list")
+	chromeSignatureURL := flags.String("chrome-signature-url", loglist3.AllLogListSignatureURL, "URL of the signature over the Chrome log list")
+	appleURL := flags.String("apple-url", appleLogListURL, "URL of the Apple log list")
+	mozillaURL := flags.String("mozilla-url", mozillaKnownLogsURL, "URL of Firefox's CTKnownLogs.h")
+	bimiURL := flags.String("bimi-url", "", "URL of the BIMI log list, in the v3 log list format (default: don't update the BIMI log list)")
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s update-loglists [-dir <directory>] [-<list>-url <url>]...\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 0 || *dir == "" {
+		flags.Usage()
+		return -1
+	}
+
+	httpClient := &http.Client{Timeout: time.Minute}
+	exitCode := 0
+	for _, logListUpdate := range []struct {
+		name   string
+		update func() (*loglist3.LogList, map[string][]byte, error)
+	}{
+		{"Chrome", func() (*loglist3.LogList, map[string][]byte, error) {
+			return downloadChromeLogList(httpClient, *chromeURL, *chromeSignatureURL)
+		}},
+		{"Apple", func() (*loglist3.LogList, map[string][]byte, error) {
+			return downloadLogList(httpClient, *appleURL, ctlint.AppleLogListFilename, ctlint.ParseAppleLogList)
+		}},
+		{"Mozilla", func() (*loglist3.LogList, map[string][]byte, error) {
+			return downloadLogList(httpClient, *mozillaURL, ctlint.MozillaKnownLogsFilename, ctlint.ParseCTKnownLogs)
+		}},
+		{"BIMI", func() (*loglist3.LogList, map[string][]byte, error) {
+			if *bimiURL == "" {
+				return nil, nil, nil
+			}
+			return downloadLogList(httpClient, *bimiURL, ctlint.BIMILogListFilename, loglist3.NewFromJSON)
+		}},
+	} {
+		logList, files, err := logListUpdate.update()
+		if err == nil && logList != nil {
+			err = writeLogListFiles(*dir, files)
+		}
+		if err != nil {
+			fmt.Printf("Error: %s log list: %v\n", logListUpdate.name, err)
+			exitCode = -1
+		} else if logList != nil {
+			fmt.Printf("%s log list updated (log_list_timestamp %s)\n", logListUpdate.name, logList.LogListTimestamp.Format(time.RFC3339))
+		}
+	}
+
+	return exitCode
+}
+
+// defaultLogListDir returns the directory in the user's cache directory in which update-loglists saves the log lists by default.
+func defaultLogListDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(cacheDir, "ctlint", "loglists")
+}
+
+// downloadChromeLogList downloads the Chrome log list and its signature, and verifies the signature using Google's log list public key, which is embedded in ctlint rather than downloaded alongside the log list.
+func downloadChromeLogList(httpClient *http.Client, logListURL, signatureURL string) (*loglist3.LogList, map[string][]byte, error) {
+	data, err := download(httpClient, logListURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := download(httpClient, signatureURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logList, err := ctlint.VerifyChromeLogList(data, signature, ctlint.GoogleLogListPublicKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logList, map[string][]byte{
+		ctlint.ChromeLogListFilename:          data,
+		ctlint.ChromeLogListSignatureFilename: signature,
+	}, nil
+}
+
+// downloadLogList downloads a log list, and checks that it can be parsed before it is saved as filename.
+func downloadLogList(httpClient *http.Client, url, filename string, parse func(data []byte) (*loglist3.LogList, error)) (*loglist3.LogList, map[string][]byte, error) {
+	data, err := download(httpClient, url)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logList, err := parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return logList, map[string][]byte{filename: data}, nil
+}
+
+func download(httpClient *http.Client, url string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	// Read one more byte than the limit, so that a log list that exceeds it is rejected instead of being truncated.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLogListSize+1))
+	if err != nil {
+		return nil, err
+	} else if len(data) > maxLogListSize {
+		return nil, fmt.Errorf("%s: larger than %d bytes", url, maxLogListSize)
+	}
+
+	return data, nil
+}
+
+// writeLogListFiles writes each file to the log list directory, replacing each existing file atomically so that a concurrent (re-)load never sees a partially written log list.
+func writeLogListFiles(dir string, files map[string][]byte) error {
+	for filename, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(filename))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		} else if err = os.WriteFile(path+".tmp", data, 0644); err != nil {
+			return err
+		} else if err = os.Rename(path+".tmp", path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
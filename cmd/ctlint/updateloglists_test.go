@@ -0,0 +1,218 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crtsh/ctlint"
+)
+
+// newTestLogListKey generates a log list signing key, and uses it in place of Google's log list public key until the test finishes.
+func newTestLogListKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spki, err := stdx509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	googleLogListPublicKeyPEM := ctlint.GoogleLogListPublicKeyPEM
+	ctlint.GoogleLogListPublicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: spki})
+	t.Cleanup(func() { ctlint.GoogleLogListPublicKeyPEM = googleLogListPublicKeyPEM })
+	return key
+}
+
+// signLogList signs a Chrome log list in the same way as Google does, with an ECDSA signature over its SHA-256 hash.
+func signLogList(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+
+	hash := sha256.Sum256(data)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
+
+// newTestCTKnownLogs returns a CTKnownLogs.h that contains the same log as the Apple log list written by newTestLog.
+func newTestCTKnownLogs(t *testing.T, logKey *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	spki, err := stdx509.MarshalPKIXPublicKey(&logKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key strings.Builder
+	for i, b := range spki {
+		if i%16 == 0 {
+			key.WriteString("\n     \"")
+		}
+		fmt.Fprintf(&key, "\\x%02x", b)
+		if i%16 == 15 || i == len(spki)-1 {
+			key.WriteString("\"")
+		}
+	}
+
+	return fmt.Appendf(nil, `static const PRTime kCTExpirationTime = INT64_C(%d);
+
+const CTLogInfo kCTLogList[] = {
+    {"Test Operator 'Test' log", CTLogState::Admissible, CTLogFormat::RFC6962,
+     0,  // no timestamp
+     0,  // operated by Test Operator%s,
+     %d},
+};
+
+const CTLogOperatorInfo kCTLogOperatorList[] = {
+    {"Test Operator", 0},
+};
+`, time.Now().Add(70*24*time.Hour).UnixMicro(), key.String(), len(spki))
+}
+
+func TestUpdateLogLists(t *testing.T) {
+	logListKey := newTestLogListKey(t)
+	appleLogListFilename, logKey := newTestLog(t)
+	appleLogList, err := os.ReadFile(appleLogListFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chromeLogList := []byte(`{"version":"1.0","log_list_timestamp":"` + time.Now().UTC().Format(time.RFC3339) + `","operators":[]}`)
+
+	files := map[string][]byte{
+		"/log_list.json":           chromeLogList,
+		"/log_list.sig":            signLogList(t, logListKey, chromeLogList),
+		"/current_log_list.json":   appleLogList,
+		"/CTKnownLogs.h":           newTestCTKnownLogs(t, logKey),
+		"/oversized_log_list.json": make([]byte, maxLogListSize+1),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if data, found := files[r.URL.Path]; found {
+			w.Write(data)
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	updateLogLists := func(dir string, args ...string) int {
+		return runUpdateLogLists(append([]string{
+			"-dir", dir,
+			"-chrome-url", server.URL + "/log_list.json",
+			"-chrome-signature-url", server.URL + "/log_list.sig",
+			"-apple-url", server.URL + "/current_log_list.json",
+			"-mozilla-url", server.URL + "/CTKnownLogs.h",
+		}, args...))
+	}
+
+	dir := t.TempDir()
+	if exitCode := updateLogLists(dir); exitCode != 0 {
+		t.Fatalf("update-loglists exited with %d", exitCode)
+	}
+	for _, filename := range []string{ctlint.ChromeLogListFilename, ctlint.ChromeLogListSignatureFilename, ctlint.AppleLogListFilename, ctlint.MozillaKnownLogsFilename} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(filename))); err != nil {
+			t.Errorf("%s was not saved: %v", filename, err)
+		}
+	}
+
+	logLists, err := ctlint.LoadLogListDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Chrome", "Apple", "Mozilla"} {
+		if logLists[name] == nil {
+			t.Errorf("%s log list was not loaded", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ctlint.BIMILogListFilename))); !os.IsNotExist(err) {
+		t.Errorf("BIMI log list was saved without -bimi-url")
+	}
+
+	// -loglist-dir makes the saved log lists current.
+	if _, err = ctlint.ReloadLogLists(ctlint.LogListFiles{Dir: dir}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ctlint.ReloadLogLists() })
+	logSPKI, err := stdx509.MarshalPKIXPublicKey(&logKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Apple", "Mozilla"} {
+		if logList := ctlint.CurrentLogListSnapshot().LogLists()[name]; logList == nil || logList.FindLogByKeyHash(sha256.Sum256(logSPKI)) == nil {
+			t.Errorf("%s log list in the log list directory was not loaded", name)
+		}
+	}
+
+	// A Chrome log list that is not signed by Google's log list key is not saved.
+	dir = t.TempDir()
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["/log_list.sig"] = signLogList(t, otherKey, chromeLogList)
+	if exitCode := updateLogLists(dir); exitCode == 0 {
+		t.Error("update-loglists succeeded with an invalid Chrome log list signature")
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ctlint.ChromeLogListFilename))); !os.IsNotExist(err) {
+		t.Error("Chrome log list with an invalid signature was saved")
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ctlint.AppleLogListFilename))); err != nil {
+		t.Errorf("Apple log list was not saved: %v", err)
+	}
+
+	// A log list that exceeds maxLogListSize is rejected rather than truncated.
+	if exitCode := updateLogLists(dir, "-bimi-url", server.URL+"/oversized_log_list.json"); exitCode == 0 {
+		t.Error("update-loglists succeeded with an oversized log list")
+	}
+	if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(ctlint.BIMILogListFilename))); !os.IsNotExist(err) {
+		t.Error("oversized BIMI log list was saved")
+	}
+}
+
+func TestLoadLogListDirVerifiesChromeLogList(t *testing.T) {
+	logListKey := newTestLogListKey(t)
+	chromeLogList := []byte(`{"version":"1.0","log_list_timestamp":"` + time.Now().UTC().Format(time.RFC3339) + `","operators":[]}`)
+	dir := t.TempDir()
+	if err := writeLogListFiles(dir, map[string][]byte{
+		ctlint.ChromeLogListFilename:          chromeLogList,
+		ctlint.ChromeLogListSignatureFilename: signLogList(t, logListKey, chromeLogList),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if logLists, err := ctlint.LoadLogListDir(dir); err != nil {
+		t.Fatal(err)
+	} else if logLists["Chrome"] == nil {
+		t.Error("Chrome log list was not loaded")
+	}
+
+	// A Chrome log list that has been modified since it was signed is rejected.
+	if err := writeLogListFiles(dir, map[string][]byte{ctlint.ChromeLogListFilename: append(chromeLogList, ' ')}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctlint.LoadLogListDir(dir); err == nil {
+		t.Error("Chrome log list with an invalid signature was loaded")
+	}
+
+	// Without its signature, the Chrome log list is rejected rather than loaded unverified.
+	if err := os.Remove(filepath.Join(dir, filepath.FromSlash(ctlint.ChromeLogListSignatureFilename))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctlint.LoadLogListDir(dir); err == nil || !strings.Contains(err.Error(), "is missing") {
+		t.Errorf("Chrome log list without a signature was not rejected: %v", err)
+	}
+}
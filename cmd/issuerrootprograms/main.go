@@ -0,0 +1,237 @@
+// Command issuerrootprograms generates ctlint's embedded map of which root programs trust each CA key disclosed in the CCADB.
+// It reads CCADB's AllCertificateRecordsReport (for each root certificate's inclusion status in each root program) and AllCertificatePEMsCSVFormat (for the certificates themselves) from the copy of the CCADB data vendored in the github.com/crtsh/ccadb_data module (at the version pinned in go.mod), and then follows every path from each CA certificate up to the included roots.
+// Only the root programs that have a CT Policy are considered.
+//
+// Usage: go run ./cmd/issuerrootprograms [-records AllCertificateRecordsReport.csv] [-pems AllCertificatePEMsCSVFormat.csv] [-out files/issuer_root_programs.tsv]
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+const (
+	ccadbDataModule                    = "github.com/crtsh/ccadb_data"
+	ccadbAllCertificateRecordsFilename = "AllCertificateRecordsReport.csv"
+	ccadbAllCertificatePEMsCSVFilename = "AllCertificatePEMsCSVFormat.csv"
+)
+
+// rootPrograms are the root programs that have a Server Authentication CT Policy.
+var rootPrograms = []string{"Apple", "Chrome", "Mozilla"}
+
+func main() {
+	exitCode := -1
+	defer func() { os.Exit(int(exitCode)) }()
+
+	recordsFilename := flag.String("records", "", "Local copy of CCADB's AllCertificateRecordsReport (default: the copy in the "+ccadbDataModule+" module)")
+	pemsFilename := flag.String("pems", "", "Local copy of CCADB's AllCertificatePEMsCSVFormat report (default: the copy in the "+ccadbDataModule+" module)")
+	outFilename := flag.String("out", "files/issuer_root_programs.tsv", "Output file")
+	flag.Parse()
+
+	var err error
+	if *recordsFilename == "" || *pemsFilename == "" {
+		var dir string
+		if dir, err = ccadbDataDir(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if *recordsFilename == "" {
+			*recordsFilename = filepath.Join(dir, ccadbAllCertificateRecordsFilename)
+		}
+		if *pemsFilename == "" {
+			*pemsFilename = filepath.Join(dir, ccadbAllCertificatePEMsCSVFilename)
+		}
+	}
+
+	includedRoots, err := readIncludedRoots(*recordsFilename)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	var in *os.File
+	if in, err = os.Open(*pemsFilename); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer in.Close()
+
+	var certs []*x509.Certificate
+	if certs, err = readCertificates(in); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	var out strings.Builder
+	out.WriteString("# Generated by cmd/issuerrootprograms from CCADB data.  DO NOT EDIT.\n")
+	out.WriteString("# SHA-256(SubjectPublicKeyInfo)\tRoot programs that trust a path to this key\n")
+	for _, line := range mapIssuerRootPrograms(certs, includedRoots) {
+		out.WriteString(line + "\n")
+	}
+
+	if err = os.WriteFile(*outFilename, []byte(out.String()), 0644); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	exitCode = 0
+}
+
+// ccadbDataDir returns the directory of the ccadb_data module required by go.mod, so that the generated map matches the CCADB data that ctlint is built with.
+func ccadbDataDir() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", ccadbDataModule).Output()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the %s module: %w", ccadbDataModule, err)
+	} else if dir := strings.TrimSpace(string(out)); dir == "" {
+		return "", fmt.Errorf("the %s module has not been downloaded; run 'go mod download %s'", ccadbDataModule, ccadbDataModule)
+	} else {
+		return dir, nil
+	}
+}
+
+// readIncludedRoots returns the root programs in which each root certificate (identified by its SHA-256 fingerprint) is included for server authentication.
+func readIncludedRoots(filename string) (map[[sha256.Size]byte][]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprintColumn := slices.Index(header, "SHA-256 Fingerprint")
+	trustBitsColumn := slices.Index(header, "Trust Bits")
+	if fingerprintColumn == -1 {
+		return nil, fmt.Errorf("%s does not contain the 'SHA-256 Fingerprint' column", filename)
+	}
+	statusColumns := make(map[string]int)
+	for _, rootProgram := range rootPrograms {
+		if statusColumns[rootProgram] = slices.Index(header, rootProgram+" Status"); statusColumns[rootProgram] == -1 {
+			return nil, fmt.Errorf("%s does not contain the '%s Status' column", filename, rootProgram)
+		}
+	}
+
+	includedRoots := make(map[[sha256.Size]byte][]string)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var fingerprint [sha256.Size]byte
+		if n, err := hex.Decode(fingerprint[:], []byte(strings.ReplaceAll(record[fingerprintColumn], ":", ""))); err != nil || n != sha256.Size {
+			continue
+		} else if trustBitsColumn != -1 && record[trustBitsColumn] != "" && !strings.Contains(record[trustBitsColumn], "Server Authentication") {
+			continue
+		}
+
+		for _, rootProgram := range rootPrograms {
+			if record[statusColumns[rootProgram]] == "Included" {
+				includedRoots[fingerprint] = append(includedRoots[fingerprint], rootProgram)
+			}
+		}
+	}
+
+	return includedRoots, nil
+}
+
+func readCertificates(in io.Reader) ([]*x509.Certificate, error) {
+	r := csv.NewReader(in)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	pemColumn := slices.Index(header, "X.509 Certificate (PEM)")
+	if pemColumn == -1 {
+		return nil, fmt.Errorf("CSV does not contain the 'X.509 Certificate (PEM)' column")
+	}
+
+	var certs []*x509.Certificate
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if block, _ := pem.Decode([]byte(record[pemColumn])); block != nil {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				certs = append(certs, cert)
+			}
+		}
+	}
+
+	return certs, nil
+}
+
+// mapIssuerRootPrograms determines, for each CA key, the root programs that include at least one root to which a certificate containing that key chains.
+func mapIssuerRootPrograms(certs []*x509.Certificate, includedRoots map[[sha256.Size]byte][]string) []string {
+	bySubject := make(map[string][]*x509.Certificate)
+	for _, cert := range certs {
+		bySubject[string(cert.RawSubject)] = append(bySubject[string(cert.RawSubject)], cert)
+	}
+
+	memo := make(map[*x509.Certificate][]string)
+	var programsFor func(cert *x509.Certificate, visiting map[*x509.Certificate]bool) []string
+	programsFor = func(cert *x509.Certificate, visiting map[*x509.Certificate]bool) []string {
+		if programs, found := memo[cert]; found {
+			return programs
+		} else if visiting[cert] {
+			return nil
+		}
+		visiting[cert] = true
+		defer delete(visiting, cert)
+
+		programs := slices.Clone(includedRoots[sha256.Sum256(cert.Raw)])
+		for _, parent := range bySubject[string(cert.RawIssuer)] {
+			if parent == cert || bytes.Equal(parent.RawSubjectPublicKeyInfo, cert.RawSubjectPublicKeyInfo) {
+				continue
+			} else if parent.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) != nil {
+				continue
+			}
+			programs = append(programs, programsFor(parent, visiting)...)
+		}
+
+		slices.Sort(programs)
+		programs = slices.Compact(programs)
+		memo[cert] = programs
+		return programs
+	}
+
+	bySPKI := make(map[string][]string)
+	for _, cert := range certs {
+		spkiSHA256 := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		key := hex.EncodeToString(spkiSHA256[:])
+		bySPKI[key] = append(bySPKI[key], programsFor(cert, make(map[*x509.Certificate]bool))...)
+	}
+
+	var lines []string
+	for key, programs := range bySPKI {
+		slices.Sort(programs)
+		lines = append(lines, key+"\t"+strings.Join(slices.Compact(programs), ","))
+	}
+	slices.Sort(lines)
+
+	return lines
+}
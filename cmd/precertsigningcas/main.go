@@ -0,0 +1,144 @@
+// Command precertsigningcas generates ctlint's embedded list of Precertificate Signing CAs from CCADB data.
+// It reads the AllCertificatePEMsCSVFormat report from the copy of the CCADB data vendored in the github.com/crtsh/ccadb_data module (at the version pinned in go.mod), and selects every disclosed CA certificate that contains the Certificate Transparency EKU.
+// The hand-maintained list of Precertificate Signing CA Common Names, which is only used as a fallback when this list is empty, is not modified.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+const (
+	ccadbDataModule                 = "github.com/crtsh/ccadb_data"
+	ccadbAllCertificatePEMsFilename = "AllCertificatePEMsCSVFormat.csv"
+)
+
+type precertSigningCA struct {
+	spkiSHA256 string
+	keyID      string
+	caOwner    string
+	commonName string
+}
+
+func main() {
+	exitCode := -1
+	defer func() { os.Exit(int(exitCode)) }()
+
+	csvFilename := flag.String("csv", "", "Local copy of CCADB's AllCertificatePEMsCSVFormat report (default: the copy in the "+ccadbDataModule+" module)")
+	outFilename := flag.String("out", "files/precert_signing_cas.tsv", "Output file for the key-based list")
+	flag.Parse()
+
+	var err error
+	if *csvFilename == "" {
+		var dir string
+		if dir, err = ccadbDataDir(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		*csvFilename = filepath.Join(dir, ccadbAllCertificatePEMsFilename)
+	}
+
+	var in *os.File
+	if in, err = os.Open(*csvFilename); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer in.Close()
+
+	var cas []precertSigningCA
+	if cas, err = readPrecertSigningCAs(in); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	var tsv strings.Builder
+	tsv.WriteString("# Generated by cmd/precertsigningcas from CCADB data.  DO NOT EDIT.\n")
+	tsv.WriteString("# SHA-256(SubjectPublicKeyInfo)\tSubject Key Identifier\tCA Owner\tCommon Name\n")
+	for _, ca := range cas {
+		fmt.Fprintf(&tsv, "%s\t%s\t%s\t%s\n", ca.spkiSHA256, ca.keyID, ca.caOwner, ca.commonName)
+	}
+
+	if err = os.WriteFile(*outFilename, []byte(tsv.String()), 0644); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	exitCode = 0
+}
+
+// ccadbDataDir returns the directory of the ccadb_data module required by go.mod, so that the generated list matches the CCADB data that ctlint is built with.
+func ccadbDataDir() (string, error) {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", ccadbDataModule).Output()
+	if err != nil {
+		return "", fmt.Errorf("could not locate the %s module: %w", ccadbDataModule, err)
+	} else if dir := strings.TrimSpace(string(out)); dir == "" {
+		return "", fmt.Errorf("the %s module has not been downloaded; run 'go mod download %s'", ccadbDataModule, ccadbDataModule)
+	} else {
+		return dir, nil
+	}
+}
+
+func readPrecertSigningCAs(in io.Reader) ([]precertSigningCA, error) {
+	r := csv.NewReader(in)
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	caOwnerColumn := slices.Index(header, "CA Owner")
+	pemColumn := slices.Index(header, "X.509 Certificate (PEM)")
+	if caOwnerColumn == -1 || pemColumn == -1 {
+		return nil, fmt.Errorf("CSV does not contain the 'CA Owner' and 'X.509 Certificate (PEM)' columns")
+	}
+
+	var cas []precertSigningCA
+	seen := make(map[string]struct{})
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		block, _ := pem.Decode([]byte(record[pemColumn]))
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !cert.IsCA || !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCertificateTransparency) {
+			continue
+		}
+
+		spkiSHA256 := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		ca := precertSigningCA{
+			spkiSHA256: hex.EncodeToString(spkiSHA256[:]),
+			keyID:      hex.EncodeToString(cert.SubjectKeyId),
+			caOwner:    strings.TrimSpace(record[caOwnerColumn]),
+			commonName: strings.TrimSpace(cert.Subject.CommonName),
+		}
+		key := ca.spkiSHA256 + "\t" + ca.keyID + "\t" + ca.caOwner + "\t" + ca.commonName
+		if _, found := seen[key]; !found {
+			seen[key] = struct{}{}
+			cas = append(cas, ca)
+		}
+	}
+
+	slices.SortFunc(cas, func(a, b precertSigningCA) int {
+		return strings.Compare(a.spkiSHA256+a.keyID+a.caOwner, b.spkiSHA256+b.keyID+b.caOwner)
+	})
+
+	return cas, nil
+}
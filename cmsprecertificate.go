@@ -0,0 +1,250 @@
+package ctlint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	encodingasn1 "encoding/asn1"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/certificate-transparency-go/asn1"
+	"github.com/google/certificate-transparency-go/x509"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var OIDContentTypeSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+// RFC9162 section 3.2: "SignedData.encapContentInfo.eContentType MUST be the OID 1.3.101.78."
+var OIDContentTypeTBSCertificate = asn1.ObjectIdentifier{1, 3, 101, 78}
+
+type cmsPrecertificate struct {
+	contentType          asn1.ObjectIdentifier
+	version              int64
+	nDigestAlgorithms    int
+	firstDigestAlgorithm []byte
+	eContentType         asn1.ObjectIdentifier
+	tbsCertificate       []byte
+	hasCertificates      bool
+	hasCRLs              bool
+	signerInfos          []cmsSignerInfo
+}
+
+type cmsSignerInfo struct {
+	version            int64
+	subjectKeyID       []byte
+	digestAlgorithm    []byte
+	hasSignedAttrs     bool
+	signatureAlgorithm []byte
+	signature          []byte
+	hasUnsignedAttrs   bool
+}
+
+// IsV2Precertificate reports whether der appears to be a RFC9162 precertificate.
+func IsV2Precertificate(der []byte) bool {
+	precert, err := parseCMSPrecertificate(der)
+	return err == nil && precert.eContentType.Equal(OIDContentTypeTBSCertificate)
+}
+
+// CheckV2Precertificate checks a RFC9162 precertificate, which is a CMS SignedData object that encapsulates the TBSCertificate of the certificate to be issued.
+// If the issuing CA's certificate is provided, the CMS signature is verified and the issuer_key_hash that logs should use is reported.
+func CheckV2Precertificate(der []byte, issuer_optional ...*x509.Certificate) []string {
+	if len(der) == 0 {
+		return []string{"E: RFC9162 precertificate not provided"}
+	}
+
+	precert, err := parseCMSPrecertificate(der)
+	if err != nil {
+		return []string{fmt.Sprintf("E: RFC9162 precertificate could not be parsed: %v", err)}
+	}
+
+	var findings []string
+	if !precert.contentType.Equal(OIDContentTypeSignedData) {
+		return []string{"E: RFC9162 precertificate is not a CMS SignedData object"}
+	} else if !precert.eContentType.Equal(OIDContentTypeTBSCertificate) {
+		return []string{"E: RFC9162 precertificate eContentType is not 1.3.101.78"}
+	}
+	findings = append(findings, "I: RFC9162 precertificate identified")
+
+	// RFC9162 section 3.2 profiles the CMS SignedData structure.
+	if precert.version != 3 {
+		findings = append(findings, "E: RFC9162 precertificate SignedData.version is not v3")
+	}
+	if precert.hasCertificates {
+		findings = append(findings, "N: RFC9162 precertificate SignedData.certificates is present")
+	}
+	if precert.hasCRLs {
+		findings = append(findings, "E: RFC9162 precertificate SignedData.crls is present")
+	}
+
+	var signerInfo *cmsSignerInfo
+	if len(precert.signerInfos) != 1 {
+		findings = append(findings, "E: RFC9162 precertificate does not contain exactly one SignerInfo")
+	} else {
+		signerInfo = &precert.signerInfos[0]
+		if signerInfo.version != 3 {
+			findings = append(findings, "E: RFC9162 precertificate SignerInfo.version is not v3")
+		}
+		if signerInfo.subjectKeyID == nil {
+			findings = append(findings, "E: RFC9162 precertificate SignerInfo.sid does not use the subjectKeyIdentifier option")
+		}
+		if precert.nDigestAlgorithms != 1 || !bytes.Equal(precert.firstDigestAlgorithm, signerInfo.digestAlgorithm) {
+			findings = append(findings, "E: RFC9162 precertificate SignedData.digestAlgorithms is not the same as SignerInfo.digestAlgorithm")
+		}
+		if signerInfo.hasSignedAttrs {
+			findings = append(findings, "E: RFC9162 precertificate SignerInfo.signedAttrs is present")
+		}
+		if signerInfo.hasUnsignedAttrs {
+			findings = append(findings, "E: RFC9162 precertificate SignerInfo.unsignedAttrs is present")
+		}
+	}
+
+	tbsCert, err := ParseTBSCertificate(precert.tbsCertificate)
+	if err != nil {
+		return append(findings, "E: RFC9162 precertificate TBSCertificate could not be parsed")
+	}
+
+	for _, ext := range tbsCert.Extensions {
+		if ext.Id.Equal(OIDExtensionTransparencyInformation) {
+			findings = append(findings, "E: Transparency information extension is present")
+		} else if ext.Id.Equal(x509.OIDExtensionCTSCT) {
+			findings = append(findings, "E: SCT list extension is present")
+		} else if ext.Id.Equal(x509.OIDExtensionCTPoison) {
+			findings = append(findings, "E: Precertificate 'poison' extension is present")
+		}
+	}
+
+	if signerInfo != nil {
+		// RFC9162 section 3.2: "SignerInfo.signatureAlgorithm MUST be the same OID as TBSCertificate.signature."
+		if !bytes.Equal(signerInfo.signatureAlgorithm, tbsSignatureAlgorithm(precert.tbsCertificate)) {
+			findings = append(findings, "E: RFC9162 precertificate SignerInfo.signatureAlgorithm differs from TBSCertificate.signature")
+		}
+		if len(issuer_optional) > 0 && issuer_optional[0] != nil {
+			findings = append(findings, checkV2PrecertificateIssuer(precert, tbsCert, signerInfo, issuer_optional[0])...)
+		}
+	}
+
+	return findings
+}
+
+func checkV2PrecertificateIssuer(precert *cmsPrecertificate, tbsCert *x509.Certificate, signerInfo *cmsSignerInfo, issuer *x509.Certificate) []string {
+	var findings []string
+
+	if !bytes.Equal(tbsCert.RawIssuer, issuer.RawSubject) {
+		findings = append(findings, "E: Issuer DN does not match the issuer certificate's Subject DN")
+	}
+	if signerInfo.subjectKeyID != nil && len(issuer.SubjectKeyId) > 0 && !bytes.Equal(signerInfo.subjectKeyID, issuer.SubjectKeyId) {
+		findings = append(findings, "E: RFC9162 precertificate SignerInfo.sid does not match the issuer certificate's Subject Key Identifier")
+	}
+
+	// RFC9162 section 3.2: "SignerInfo.signature MUST be calculated using the same algorithm and issuer key that will be used to create the final certificate."
+	// Since signedAttrs is absent, the signature is calculated over the TBSCertificate itself.
+	if err := issuer.CheckSignature(tbsCert.SignatureAlgorithm, precert.tbsCertificate, signerInfo.signature); err != nil {
+		return append(findings, "E: RFC9162 precertificate signature cannot be verified using the issuer certificate's public key")
+	}
+
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	return append(findings, fmt.Sprintf("I: issuer_key_hash for this precertificate's log entries is %s", hex.EncodeToString(sha256IssuerSPKI[:])))
+}
+
+func parseCMSPrecertificate(der []byte) (*cmsPrecertificate, error) {
+	precert := &cmsPrecertificate{}
+
+	// RFC5652 section 3: ContentInfo.
+	input := cryptobyte.String(der)
+	var contentInfo, signedDataWrapper, signedData cryptobyte.String
+	var contentType encodingasn1.ObjectIdentifier
+	if !input.ReadASN1(&contentInfo, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, fmt.Errorf("malformed ContentInfo")
+	} else if !contentInfo.ReadASN1ObjectIdentifier(&contentType) {
+		return nil, fmt.Errorf("malformed ContentInfo.contentType")
+	}
+	precert.contentType = asn1.ObjectIdentifier(contentType)
+	if !precert.contentType.Equal(OIDContentTypeSignedData) {
+		return precert, nil
+	} else if !contentInfo.ReadASN1(&signedDataWrapper, cbasn1.Tag(0).Constructed().ContextSpecific()) || !signedDataWrapper.ReadASN1(&signedData, cbasn1.SEQUENCE) {
+		return nil, fmt.Errorf("malformed SignedData")
+	}
+
+	// RFC5652 section 5.1: SignedData.
+	var digestAlgorithms, encapContentInfo, signerInfos cryptobyte.String
+	var eContentType encodingasn1.ObjectIdentifier
+	if !signedData.ReadASN1Int64WithTag(&precert.version, cbasn1.INTEGER) || !signedData.ReadASN1(&digestAlgorithms, cbasn1.SET) || !signedData.ReadASN1(&encapContentInfo, cbasn1.SEQUENCE) {
+		return nil, fmt.Errorf("malformed SignedData")
+	} else if !encapContentInfo.ReadASN1ObjectIdentifier(&eContentType) {
+		return nil, fmt.Errorf("malformed SignedData.encapContentInfo")
+	}
+	precert.eContentType = asn1.ObjectIdentifier(eContentType)
+
+	for !digestAlgorithms.Empty() {
+		var digestAlgorithm cryptobyte.String
+		if !digestAlgorithms.ReadASN1Element(&digestAlgorithm, cbasn1.SEQUENCE) {
+			return nil, fmt.Errorf("malformed SignedData.digestAlgorithms")
+		}
+		if precert.nDigestAlgorithms == 0 {
+			precert.firstDigestAlgorithm = digestAlgorithm
+		}
+		precert.nDigestAlgorithms++
+	}
+
+	var eContentWrapper cryptobyte.String
+	var hasEContent bool
+	if !encapContentInfo.ReadOptionalASN1(&eContentWrapper, &hasEContent, cbasn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, fmt.Errorf("malformed SignedData.encapContentInfo.eContent")
+	} else if !hasEContent {
+		return nil, fmt.Errorf("SignedData.encapContentInfo.eContent is absent")
+	}
+	var eContent cryptobyte.String
+	if !eContentWrapper.ReadASN1(&eContent, cbasn1.OCTET_STRING) {
+		return nil, fmt.Errorf("malformed SignedData.encapContentInfo.eContent")
+	}
+	precert.tbsCertificate = eContent
+
+	precert.hasCertificates = signedData.PeekASN1Tag(cbasn1.Tag(0).Constructed().ContextSpecific())
+	if !signedData.SkipOptionalASN1(cbasn1.Tag(0).Constructed().ContextSpecific()) {
+		return nil, fmt.Errorf("malformed SignedData.certificates")
+	}
+	precert.hasCRLs = signedData.PeekASN1Tag(cbasn1.Tag(1).Constructed().ContextSpecific())
+	if !signedData.SkipOptionalASN1(cbasn1.Tag(1).Constructed().ContextSpecific()) {
+		return nil, fmt.Errorf("malformed SignedData.crls")
+	} else if !signedData.ReadASN1(&signerInfos, cbasn1.SET) || !signedData.Empty() {
+		return nil, fmt.Errorf("malformed SignedData.signerInfos")
+	}
+
+	// RFC5652 section 5.3: SignerInfo.
+	for !signerInfos.Empty() {
+		var signerInfo cryptobyte.String
+		var si cmsSignerInfo
+		if !signerInfos.ReadASN1(&signerInfo, cbasn1.SEQUENCE) || !signerInfo.ReadASN1Int64WithTag(&si.version, cbasn1.INTEGER) {
+			return nil, fmt.Errorf("malformed SignerInfo")
+		}
+
+		if signerInfo.PeekASN1Tag(cbasn1.Tag(0).ContextSpecific()) {
+			var subjectKeyID cryptobyte.String
+			if !signerInfo.ReadASN1(&subjectKeyID, cbasn1.Tag(0).ContextSpecific()) {
+				return nil, fmt.Errorf("malformed SignerInfo.sid")
+			}
+			si.subjectKeyID = append([]byte{}, subjectKeyID...)
+		} else if !signerInfo.SkipASN1(cbasn1.SEQUENCE) {
+			return nil, fmt.Errorf("malformed SignerInfo.sid")
+		}
+
+		var digestAlgorithm, signatureAlgorithm, signature cryptobyte.String
+		if !signerInfo.ReadASN1Element(&digestAlgorithm, cbasn1.SEQUENCE) {
+			return nil, fmt.Errorf("malformed SignerInfo.digestAlgorithm")
+		}
+		si.hasSignedAttrs = signerInfo.PeekASN1Tag(cbasn1.Tag(0).Constructed().ContextSpecific())
+		if !signerInfo.SkipOptionalASN1(cbasn1.Tag(0).Constructed().ContextSpecific()) || !signerInfo.ReadASN1Element(&signatureAlgorithm, cbasn1.SEQUENCE) || !signerInfo.ReadASN1(&signature, cbasn1.OCTET_STRING) {
+			return nil, fmt.Errorf("malformed SignerInfo")
+		}
+		si.hasUnsignedAttrs = signerInfo.PeekASN1Tag(cbasn1.Tag(1).Constructed().ContextSpecific())
+		if !signerInfo.SkipOptionalASN1(cbasn1.Tag(1).Constructed().ContextSpecific()) || !signerInfo.Empty() {
+			return nil, fmt.Errorf("malformed SignerInfo")
+		}
+
+		si.digestAlgorithm, si.signatureAlgorithm, si.signature = digestAlgorithm, signatureAlgorithm, signature
+		precert.signerInfos = append(precert.signerInfos, si)
+	}
+
+	return precert, nil
+}
@@ -0,0 +1,178 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+)
+
+// Firefox's CTKnownLogs.h, which is generated by getCTKnownLogs.py, defines kCTExpirationTime, the kCTLogList array of CTLogInfo structures, and the kCTLogOperatorList array of CTLogOperatorInfo structures:
+//
+//	static const PRTime kCTExpirationTime = INT64_C(...);
+//	const CTLogInfo kCTLogList[] = {
+//	    {"<name>", CTLogState::Admissible, CTLogFormat::RFC6962,
+//	     0,  // no timestamp
+//	     0,  // operated by <operator>
+//	     "\x30\x59..."
+//	     "...",
+//	     91},
+//	    ...
+//	};
+//	const CTLogOperatorInfo kCTLogOperatorList[] = {
+//	    {"<operator>", 0},
+//	    ...
+//	};
+//
+// Older versions of CTKnownLogs.h have no CTLogFormat field, and use CTLogStatus::Included and CTLogStatus::Disqualified instead of CTLogState::Admissible and CTLogState::Retired.
+var (
+	ctKnownLogsExpirationTimePattern = regexp.MustCompile(`kCTExpirationTime\s*=\s*INT64_C\((\d+)\)`)
+	ctKnownLogsLogPattern            = regexp.MustCompile(`\{\s*("(?:[^"\\]|\\.)*"),\s*CTLog(?:State|Status)::(\w+),\s*(?:CTLogFormat::(\w+),\s*)?(\d+),[^\n]*\n\s*(\d+),[^\n]*\n((?:\s*"(?:[^"\\]|\\.)*")+),\s*(\d+)\s*\}`)
+	ctKnownLogsOperatorPattern       = regexp.MustCompile(`\{\s*("(?:[^"\\]|\\.)*"),\s*(\d+)\s*\}`)
+	ctKnownLogsStringPattern         = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// ParseCTKnownLogs builds a log list from Firefox's CTKnownLogs.h, which is the list of logs that a particular Firefox release enforces the Mozilla CT Policy against.
+// Admissible logs are treated as Usable and Retired logs as Retired.  The log list timestamp is derived from kCTExpirationTime, which is 70 days after the log list was generated.
+func ParseCTKnownLogs(data []byte) (*loglist3.LogList, error) {
+	header := string(data)
+	logList := &loglist3.LogList{}
+
+	if match := ctKnownLogsExpirationTimePattern.FindStringSubmatch(header); match == nil {
+		return nil, errors.New("kCTExpirationTime not found")
+	} else if expirationTime, err := strconv.ParseInt(match[1], 10, 64); err != nil {
+		return nil, fmt.Errorf("kCTExpirationTime could not be parsed: %v", err)
+	} else {
+		// kCTExpirationTime is a PRTime, which counts microseconds since the epoch.
+		logList.LogListTimestamp = time.UnixMicro(expirationTime).Add(-70 * 24 * time.Hour).UTC()
+	}
+
+	_, operatorList, found := strings.Cut(header, "kCTLogOperatorList[] = {")
+	if !found {
+		return nil, errors.New("kCTLogOperatorList not found")
+	}
+	operatorList, _, _ = strings.Cut(operatorList, "};")
+	operators := make(map[string]*loglist3.Operator)
+	for _, match := range ctKnownLogsOperatorPattern.FindAllStringSubmatch(operatorList, -1) {
+		name, err := unquoteCString(match[1])
+		if err != nil {
+			return nil, err
+		}
+		operators[match[2]] = &loglist3.Operator{Name: name}
+		logList.Operators = append(logList.Operators, operators[match[2]])
+	}
+
+	_, ctLogList, found := strings.Cut(header, "kCTLogList[] = {")
+	if !found {
+		return nil, errors.New("kCTLogList not found")
+	}
+	ctLogList, _, _ = strings.Cut(ctLogList, "\n};")
+	nLogs := 0
+	for _, match := range ctKnownLogsLogPattern.FindAllStringSubmatch(ctLogList, -1) {
+		description, err := unquoteCString(match[1])
+		if err != nil {
+			return nil, err
+		}
+
+		var key []byte
+		for _, keyString := range ctKnownLogsStringPattern.FindAllString(match[6], -1) {
+			keyPart, err := unquoteCString(keyString)
+			if err != nil {
+				return nil, err
+			}
+			key = append(key, keyPart...)
+		}
+		if keyLength, err := strconv.Atoi(match[7]); err != nil || keyLength != len(key) {
+			return nil, fmt.Errorf("key length of %q does not match its key", description)
+		}
+
+		operator, found := operators[match[5]]
+		if !found {
+			return nil, fmt.Errorf("operator of %q is not in kCTLogOperatorList", description)
+		}
+
+		timestamp, err := strconv.ParseInt(match[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("timestamp of %q could not be parsed: %v", description, err)
+		}
+		state := &loglist3.LogStates{}
+		switch match[2] {
+		case "Admissible", "Included":
+			state.Usable = &loglist3.LogState{Timestamp: time.UnixMilli(timestamp).UTC()}
+		case "Retired", "Disqualified":
+			state.Retired = &loglist3.LogState{Timestamp: time.UnixMilli(timestamp).UTC()}
+		default:
+			return nil, fmt.Errorf("state of %q (%s) is not recognized", description, match[2])
+		}
+
+		logID := sha256.Sum256(key)
+		switch match[3] {
+		case "", "RFC6962":
+			operator.Logs = append(operator.Logs, &loglist3.Log{Description: description, LogID: logID[:], Key: key, State: state})
+		case "Tiled":
+			operator.TiledLogs = append(operator.TiledLogs, &loglist3.TiledLog{Description: description, LogID: logID[:], Key: key, State: state})
+		default:
+			return nil, fmt.Errorf("format of %q (%s) is not recognized", description, match[3])
+		}
+		nLogs++
+	}
+
+	if nLogs == 0 {
+		return nil, errors.New("kCTLogList contains no logs")
+	}
+
+	return logList, nil
+}
+
+// unquoteCString decodes a double-quoted C string literal, such as those in CTKnownLogs.h.
+func unquoteCString(quoted string) (string, error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return "", fmt.Errorf("%s is not a string literal", quoted)
+	}
+
+	var unquoted []byte
+	for s := quoted[1 : len(quoted)-1]; s != ""; {
+		if s[0] != '\\' {
+			unquoted, s = append(unquoted, s[0]), s[1:]
+			continue
+		} else if len(s) < 2 {
+			return "", fmt.Errorf("%s contains an incomplete escape sequence", quoted)
+		}
+
+		switch s[1] {
+		case 'x':
+			n := 2
+			for n < len(s) && n < 4 && strings.IndexByte("0123456789abcdefABCDEF", s[n]) >= 0 {
+				n++
+			}
+			b, err := strconv.ParseUint(s[2:n], 16, 8)
+			if err != nil {
+				return "", fmt.Errorf("%s contains an invalid hexadecimal escape sequence", quoted)
+			}
+			unquoted, s = append(unquoted, byte(b)), s[n:]
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			n := 1
+			for n < len(s) && n < 4 && s[n] >= '0' && s[n] <= '7' {
+				n++
+			}
+			b, err := strconv.ParseUint(s[1:n], 8, 8)
+			if err != nil {
+				return "", fmt.Errorf("%s contains an invalid octal escape sequence", quoted)
+			}
+			unquoted, s = append(unquoted, byte(b)), s[n:]
+		case 'n':
+			unquoted, s = append(unquoted, '\n'), s[2:]
+		case 't':
+			unquoted, s = append(unquoted, '\t'), s[2:]
+		default:
+			unquoted, s = append(unquoted, s[1]), s[2:]
+		}
+	}
+
+	return string(unquoted), nil
+}
@@ -0,0 +1,104 @@
+package ctlint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+)
+
+// testdata/CTKnownLogs.h and testdata/CTKnownLogs_CTLogStatus.h follow the layout that getCTKnownLogs.py generates, in its current and older (CTLogStatus) forms respectively.  The keys are test keys: the current form escapes every byte in hexadecimal, and the older form escapes non-printable bytes in octal.
+func TestParseCTKnownLogs(t *testing.T) {
+	wantLogListTimestamp := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-70 * 24 * time.Hour)
+	wantRetired := time.Date(2020, time.May, 4, 0, 0, 0, 0, time.UTC)
+
+	var keys [][]byte
+	for _, filename := range []string{"testdata/CTKnownLogs.h", "testdata/CTKnownLogs_CTLogStatus.h"} {
+		t.Run(filename, func(t *testing.T) {
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logList, err := ParseCTKnownLogs(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !logList.LogListTimestamp.Equal(wantLogListTimestamp) {
+				t.Errorf("log_list_timestamp = %s, want %s", logList.LogListTimestamp, wantLogListTimestamp)
+			}
+			if len(logList.Operators) == 0 || logList.Operators[0].Name != "Example" || len(logList.Operators[0].Logs) != 2 {
+				t.Fatalf("unexpected operators: %+v", logList.Operators)
+			}
+
+			argon, xenon := logList.Operators[0].Logs[0], logList.Operators[0].Logs[1]
+			if argon.Description != "Example 'Argon2026h1' log" || argon.State.LogStatus() != loglist3.UsableLogStatus {
+				t.Errorf("unexpected log %q (%s)", argon.Description, argon.State.LogStatus())
+			}
+			if xenon.Description != "Example 'Xenon2020' log" || xenon.State.LogStatus() != loglist3.RetiredLogStatus || !xenon.State.Retired.Timestamp.Equal(wantRetired) {
+				t.Errorf("unexpected log %q (%s)", xenon.Description, xenon.State.LogStatus())
+			}
+
+			for _, log := range []*loglist3.Log{argon, xenon} {
+				if _, err := x509.ParsePKIXPublicKey(log.Key); err != nil {
+					t.Errorf("key of %q could not be parsed: %v", log.Description, err)
+				} else if logID := sha256.Sum256(log.Key); !bytes.Equal(log.LogID, logID[:]) {
+					t.Errorf("log ID of %q does not match its key", log.Description)
+				}
+				keys = append(keys, log.Key)
+			}
+		})
+	}
+
+	// Both files contain the same keys, so the hexadecimal and octal escape sequences must decode to the same bytes.
+	if len(keys) == 4 && (!bytes.Equal(keys[0], keys[2]) || !bytes.Equal(keys[1], keys[3])) {
+		t.Error("keys decoded from hexadecimal and octal escape sequences differ")
+	}
+}
+
+func TestParseCTKnownLogsTiledLog(t *testing.T) {
+	data, err := os.ReadFile("testdata/CTKnownLogs.h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logList, err := ParseCTKnownLogs(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logList.Operators) != 2 || len(logList.Operators[1].TiledLogs) != 1 {
+		t.Fatalf("unexpected operators: %+v", logList.Operators)
+	} else if tiledLog := logList.Operators[1].TiledLogs[0]; tiledLog.Description != `Example "Tiles" 2026h1` || tiledLog.State.LogStatus() != loglist3.UsableLogStatus {
+		t.Errorf("unexpected tiled log %q (%s)", tiledLog.Description, tiledLog.State.LogStatus())
+	}
+}
+
+func TestUnquoteCString(t *testing.T) {
+	for _, test := range []struct {
+		quoted, want string
+	}{
+		{`"\x30\x59\x30\x13"`, "\x30\x59\x30\x13"},
+		{`"\x3"`, "\x03"},
+		{`"\0Y0\023\006\007*"`, "\x00Y0\x13\x06\x07*"},
+		{`"\0178"`, "\x0f8"},
+		{`"\377\1\12"`, "\xff\x01\x0a"},
+		{`"a\"b\\c\?"`, `a"b\c?`},
+		{`"\n\t"`, "\n\t"},
+	} {
+		if got, err := unquoteCString(test.quoted); err != nil {
+			t.Errorf("unquoteCString(%s): %v", test.quoted, err)
+		} else if got != test.want {
+			t.Errorf("unquoteCString(%s) = %q, want %q", test.quoted, got, test.want)
+		}
+	}
+
+	for _, quoted := range []string{`"\"`, `"\xg0"`, `"\400"`, `unquoted`} {
+		if _, err := unquoteCString(quoted); err == nil {
+			t.Errorf("unquoteCString(%s) succeeded", quoted)
+		}
+	}
+}
@@ -0,0 +1,738 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: ctlint.proto
+
+package ctlintpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PolicyGroup int32
+
+const (
+	// The policy group is detected from the certificate.
+	PolicyGroup_POLICY_GROUP_UNSPECIFIED           PolicyGroup = 0
+	PolicyGroup_POLICY_GROUP_SERVER_AUTHENTICATION PolicyGroup = 1
+	PolicyGroup_POLICY_GROUP_MARK                  PolicyGroup = 2
+	PolicyGroup_POLICY_GROUP_SMIME                 PolicyGroup = 3
+	PolicyGroup_POLICY_GROUP_CODE_SIGNING          PolicyGroup = 4
+)
+
+// Enum value maps for PolicyGroup.
+var (
+	PolicyGroup_name = map[int32]string{
+		0: "POLICY_GROUP_UNSPECIFIED",
+		1: "POLICY_GROUP_SERVER_AUTHENTICATION",
+		2: "POLICY_GROUP_MARK",
+		3: "POLICY_GROUP_SMIME",
+		4: "POLICY_GROUP_CODE_SIGNING",
+	}
+	PolicyGroup_value = map[string]int32{
+		"POLICY_GROUP_UNSPECIFIED":           0,
+		"POLICY_GROUP_SERVER_AUTHENTICATION": 1,
+		"POLICY_GROUP_MARK":                  2,
+		"POLICY_GROUP_SMIME":                 3,
+		"POLICY_GROUP_CODE_SIGNING":          4,
+	}
+)
+
+func (x PolicyGroup) Enum() *PolicyGroup {
+	p := new(PolicyGroup)
+	*p = x
+	return p
+}
+
+func (x PolicyGroup) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PolicyGroup) Descriptor() protoreflect.EnumDescriptor {
+	return file_ctlint_proto_enumTypes[0].Descriptor()
+}
+
+func (PolicyGroup) Type() protoreflect.EnumType {
+	return &file_ctlint_proto_enumTypes[0]
+}
+
+func (x PolicyGroup) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PolicyGroup.Descriptor instead.
+func (PolicyGroup) EnumDescriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{0}
+}
+
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNSPECIFIED Severity = 0
+	Severity_SEVERITY_INFO        Severity = 1
+	Severity_SEVERITY_NOTICE      Severity = 2
+	Severity_SEVERITY_WARNING     Severity = 3
+	Severity_SEVERITY_ERROR       Severity = 4
+	Severity_SEVERITY_FATAL       Severity = 5
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_INFO",
+		2: "SEVERITY_NOTICE",
+		3: "SEVERITY_WARNING",
+		4: "SEVERITY_ERROR",
+		5: "SEVERITY_FATAL",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_INFO":        1,
+		"SEVERITY_NOTICE":      2,
+		"SEVERITY_WARNING":     3,
+		"SEVERITY_ERROR":       4,
+		"SEVERITY_FATAL":       5,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_ctlint_proto_enumTypes[1].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_ctlint_proto_enumTypes[1]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{1}
+}
+
+type LintCertificateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DER-encoded certificate.
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// DER-encoded issuer certificate (optional).  If absent, the issuer's key is sought in the available CCADB data.
+	IssuerCertificate []byte      `protobuf:"bytes,2,opt,name=issuer_certificate,json=issuerCertificate,proto3" json:"issuer_certificate,omitempty"`
+	PolicyGroup       PolicyGroup `protobuf:"varint,3,opt,name=policy_group,json=policyGroup,proto3,enum=ctlint.v1.PolicyGroup" json:"policy_group,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *LintCertificateRequest) Reset() {
+	*x = LintCertificateRequest{}
+	mi := &file_ctlint_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintCertificateRequest) ProtoMessage() {}
+
+func (x *LintCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintCertificateRequest.ProtoReflect.Descriptor instead.
+func (*LintCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LintCertificateRequest) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *LintCertificateRequest) GetIssuerCertificate() []byte {
+	if x != nil {
+		return x.IssuerCertificate
+	}
+	return nil
+}
+
+func (x *LintCertificateRequest) GetPolicyGroup() PolicyGroup {
+	if x != nil {
+		return x.PolicyGroup
+	}
+	return PolicyGroup_POLICY_GROUP_UNSPECIFIED
+}
+
+type LintPrecertificateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DER-encoded precertificate.
+	Precertificate []byte `protobuf:"bytes,1,opt,name=precertificate,proto3" json:"precertificate,omitempty"`
+	// DER-encoded certificate of the CA or Precertificate Signing Certificate that signed the precertificate (optional).
+	IssuerCertificate []byte `protobuf:"bytes,2,opt,name=issuer_certificate,json=issuerCertificate,proto3" json:"issuer_certificate,omitempty"`
+	// DER-encoded certificate of the CA that issued the Precertificate Signing Certificate, if issuer_certificate is one (optional).
+	CaCertificate []byte `protobuf:"bytes,3,opt,name=ca_certificate,json=caCertificate,proto3" json:"ca_certificate,omitempty"`
+	// Only used when issuer_certificate is absent.
+	PolicyGroup   PolicyGroup `protobuf:"varint,4,opt,name=policy_group,json=policyGroup,proto3,enum=ctlint.v1.PolicyGroup" json:"policy_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintPrecertificateRequest) Reset() {
+	*x = LintPrecertificateRequest{}
+	mi := &file_ctlint_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintPrecertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintPrecertificateRequest) ProtoMessage() {}
+
+func (x *LintPrecertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintPrecertificateRequest.ProtoReflect.Descriptor instead.
+func (*LintPrecertificateRequest) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LintPrecertificateRequest) GetPrecertificate() []byte {
+	if x != nil {
+		return x.Precertificate
+	}
+	return nil
+}
+
+func (x *LintPrecertificateRequest) GetIssuerCertificate() []byte {
+	if x != nil {
+		return x.IssuerCertificate
+	}
+	return nil
+}
+
+func (x *LintPrecertificateRequest) GetCaCertificate() []byte {
+	if x != nil {
+		return x.CaCertificate
+	}
+	return nil
+}
+
+func (x *LintPrecertificateRequest) GetPolicyGroup() PolicyGroup {
+	if x != nil {
+		return x.PolicyGroup
+	}
+	return PolicyGroup_POLICY_GROUP_UNSPECIFIED
+}
+
+type LintSCTsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DER-encoded precertificate, signed by the CA that will issue the final certificate.
+	Precertificate []byte `protobuf:"bytes,1,opt,name=precertificate,proto3" json:"precertificate,omitempty"`
+	// DER-encoded certificate of the CA that will issue the final certificate (optional).  If absent, the CA's key is sought in the available CCADB data.
+	IssuerCertificate []byte `protobuf:"bytes,2,opt,name=issuer_certificate,json=issuerCertificate,proto3" json:"issuer_certificate,omitempty"`
+	// TLS-encoded SignedCertificateTimestampList, as it will appear in the final certificate's SCT list extension.
+	SctList       []byte      `protobuf:"bytes,3,opt,name=sct_list,json=sctList,proto3" json:"sct_list,omitempty"`
+	PolicyGroup   PolicyGroup `protobuf:"varint,4,opt,name=policy_group,json=policyGroup,proto3,enum=ctlint.v1.PolicyGroup" json:"policy_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintSCTsRequest) Reset() {
+	*x = LintSCTsRequest{}
+	mi := &file_ctlint_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintSCTsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintSCTsRequest) ProtoMessage() {}
+
+func (x *LintSCTsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintSCTsRequest.ProtoReflect.Descriptor instead.
+func (*LintSCTsRequest) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LintSCTsRequest) GetPrecertificate() []byte {
+	if x != nil {
+		return x.Precertificate
+	}
+	return nil
+}
+
+func (x *LintSCTsRequest) GetIssuerCertificate() []byte {
+	if x != nil {
+		return x.IssuerCertificate
+	}
+	return nil
+}
+
+func (x *LintSCTsRequest) GetSctList() []byte {
+	if x != nil {
+		return x.SctList
+	}
+	return nil
+}
+
+func (x *LintSCTsRequest) GetPolicyGroup() PolicyGroup {
+	if x != nil {
+		return x.PolicyGroup
+	}
+	return PolicyGroup_POLICY_GROUP_UNSPECIFIED
+}
+
+type Finding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      Severity               `protobuf:"varint,1,opt,name=severity,proto3,enum=ctlint.v1.Severity" json:"severity,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Finding) Reset() {
+	*x = Finding{}
+	mi := &file_ctlint_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Finding) ProtoMessage() {}
+
+func (x *Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
+func (*Finding) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Finding) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+func (x *Finding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type LintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Findings      []*Finding             `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintResponse) Reset() {
+	*x = LintResponse{}
+	mi := &file_ctlint_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintResponse) ProtoMessage() {}
+
+func (x *LintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintResponse.ProtoReflect.Descriptor instead.
+func (*LintResponse) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LintResponse) GetFindings() []*Finding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+type LintBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Copied to the corresponding response, so that responses can be matched to requests.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*LintBatchRequest_Certificate
+	//	*LintBatchRequest_Precertificate
+	//	*LintBatchRequest_Scts
+	Request       isLintBatchRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintBatchRequest) Reset() {
+	*x = LintBatchRequest{}
+	mi := &file_ctlint_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintBatchRequest) ProtoMessage() {}
+
+func (x *LintBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintBatchRequest.ProtoReflect.Descriptor instead.
+func (*LintBatchRequest) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LintBatchRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LintBatchRequest) GetRequest() isLintBatchRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *LintBatchRequest) GetCertificate() *LintCertificateRequest {
+	if x != nil {
+		if x, ok := x.Request.(*LintBatchRequest_Certificate); ok {
+			return x.Certificate
+		}
+	}
+	return nil
+}
+
+func (x *LintBatchRequest) GetPrecertificate() *LintPrecertificateRequest {
+	if x != nil {
+		if x, ok := x.Request.(*LintBatchRequest_Precertificate); ok {
+			return x.Precertificate
+		}
+	}
+	return nil
+}
+
+func (x *LintBatchRequest) GetScts() *LintSCTsRequest {
+	if x != nil {
+		if x, ok := x.Request.(*LintBatchRequest_Scts); ok {
+			return x.Scts
+		}
+	}
+	return nil
+}
+
+type isLintBatchRequest_Request interface {
+	isLintBatchRequest_Request()
+}
+
+type LintBatchRequest_Certificate struct {
+	Certificate *LintCertificateRequest `protobuf:"bytes,2,opt,name=certificate,proto3,oneof"`
+}
+
+type LintBatchRequest_Precertificate struct {
+	Precertificate *LintPrecertificateRequest `protobuf:"bytes,3,opt,name=precertificate,proto3,oneof"`
+}
+
+type LintBatchRequest_Scts struct {
+	Scts *LintSCTsRequest `protobuf:"bytes,4,opt,name=scts,proto3,oneof"`
+}
+
+func (*LintBatchRequest_Certificate) isLintBatchRequest_Request() {}
+
+func (*LintBatchRequest_Precertificate) isLintBatchRequest_Request() {}
+
+func (*LintBatchRequest_Scts) isLintBatchRequest_Request() {}
+
+type LintBatchResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Response *LintResponse          `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	// Set instead of response if the request could not be linted (e.g., because a certificate could not be parsed).
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LintBatchResponse) Reset() {
+	*x = LintBatchResponse{}
+	mi := &file_ctlint_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LintBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LintBatchResponse) ProtoMessage() {}
+
+func (x *LintBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ctlint_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LintBatchResponse.ProtoReflect.Descriptor instead.
+func (*LintBatchResponse) Descriptor() ([]byte, []int) {
+	return file_ctlint_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LintBatchResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LintBatchResponse) GetResponse() *LintResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *LintBatchResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_ctlint_proto protoreflect.FileDescriptor
+
+const file_ctlint_proto_rawDesc = "" +
+	"\n" +
+	"\fctlint.proto\x12\tctlint.v1\"\xa4\x01\n" +
+	"\x16LintCertificateRequest\x12 \n" +
+	"\vcertificate\x18\x01 \x01(\fR\vcertificate\x12-\n" +
+	"\x12issuer_certificate\x18\x02 \x01(\fR\x11issuerCertificate\x129\n" +
+	"\fpolicy_group\x18\x03 \x01(\x0e2\x16.ctlint.v1.PolicyGroupR\vpolicyGroup\"\xd4\x01\n" +
+	"\x19LintPrecertificateRequest\x12&\n" +
+	"\x0eprecertificate\x18\x01 \x01(\fR\x0eprecertificate\x12-\n" +
+	"\x12issuer_certificate\x18\x02 \x01(\fR\x11issuerCertificate\x12%\n" +
+	"\x0eca_certificate\x18\x03 \x01(\fR\rcaCertificate\x129\n" +
+	"\fpolicy_group\x18\x04 \x01(\x0e2\x16.ctlint.v1.PolicyGroupR\vpolicyGroup\"\xbe\x01\n" +
+	"\x0fLintSCTsRequest\x12&\n" +
+	"\x0eprecertificate\x18\x01 \x01(\fR\x0eprecertificate\x12-\n" +
+	"\x12issuer_certificate\x18\x02 \x01(\fR\x11issuerCertificate\x12\x19\n" +
+	"\bsct_list\x18\x03 \x01(\fR\asctList\x129\n" +
+	"\fpolicy_group\x18\x04 \x01(\x0e2\x16.ctlint.v1.PolicyGroupR\vpolicyGroup\"T\n" +
+	"\aFinding\x12/\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x13.ctlint.v1.SeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\">\n" +
+	"\fLintResponse\x12.\n" +
+	"\bfindings\x18\x01 \x03(\v2\x12.ctlint.v1.FindingR\bfindings\"\xf6\x01\n" +
+	"\x10LintBatchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12E\n" +
+	"\vcertificate\x18\x02 \x01(\v2!.ctlint.v1.LintCertificateRequestH\x00R\vcertificate\x12N\n" +
+	"\x0eprecertificate\x18\x03 \x01(\v2$.ctlint.v1.LintPrecertificateRequestH\x00R\x0eprecertificate\x120\n" +
+	"\x04scts\x18\x04 \x01(\v2\x1a.ctlint.v1.LintSCTsRequestH\x00R\x04sctsB\t\n" +
+	"\arequest\"n\n" +
+	"\x11LintBatchResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\bresponse\x18\x02 \x01(\v2\x17.ctlint.v1.LintResponseR\bresponse\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error*\xa1\x01\n" +
+	"\vPolicyGroup\x12\x1c\n" +
+	"\x18POLICY_GROUP_UNSPECIFIED\x10\x00\x12&\n" +
+	"\"POLICY_GROUP_SERVER_AUTHENTICATION\x10\x01\x12\x15\n" +
+	"\x11POLICY_GROUP_MARK\x10\x02\x12\x16\n" +
+	"\x12POLICY_GROUP_SMIME\x10\x03\x12\x1d\n" +
+	"\x19POLICY_GROUP_CODE_SIGNING\x10\x04*\x8a\x01\n" +
+	"\bSeverity\x12\x18\n" +
+	"\x14SEVERITY_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSEVERITY_INFO\x10\x01\x12\x13\n" +
+	"\x0fSEVERITY_NOTICE\x10\x02\x12\x14\n" +
+	"\x10SEVERITY_WARNING\x10\x03\x12\x12\n" +
+	"\x0eSEVERITY_ERROR\x10\x04\x12\x12\n" +
+	"\x0eSEVERITY_FATAL\x10\x052\xb9\x02\n" +
+	"\x06CTLint\x12M\n" +
+	"\x0fLintCertificate\x12!.ctlint.v1.LintCertificateRequest\x1a\x17.ctlint.v1.LintResponse\x12S\n" +
+	"\x12LintPrecertificate\x12$.ctlint.v1.LintPrecertificateRequest\x1a\x17.ctlint.v1.LintResponse\x12?\n" +
+	"\bLintSCTs\x12\x1a.ctlint.v1.LintSCTsRequest\x1a\x17.ctlint.v1.LintResponse\x12J\n" +
+	"\tLintBatch\x12\x1b.ctlint.v1.LintBatchRequest\x1a\x1c.ctlint.v1.LintBatchResponse(\x010\x01B\"Z github.com/crtsh/ctlint/ctlintpbb\x06proto3"
+
+var (
+	file_ctlint_proto_rawDescOnce sync.Once
+	file_ctlint_proto_rawDescData []byte
+)
+
+func file_ctlint_proto_rawDescGZIP() []byte {
+	file_ctlint_proto_rawDescOnce.Do(func() {
+		file_ctlint_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ctlint_proto_rawDesc), len(file_ctlint_proto_rawDesc)))
+	})
+	return file_ctlint_proto_rawDescData
+}
+
+var file_ctlint_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ctlint_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_ctlint_proto_goTypes = []any{
+	(PolicyGroup)(0),                  // 0: ctlint.v1.PolicyGroup
+	(Severity)(0),                     // 1: ctlint.v1.Severity
+	(*LintCertificateRequest)(nil),    // 2: ctlint.v1.LintCertificateRequest
+	(*LintPrecertificateRequest)(nil), // 3: ctlint.v1.LintPrecertificateRequest
+	(*LintSCTsRequest)(nil),           // 4: ctlint.v1.LintSCTsRequest
+	(*Finding)(nil),                   // 5: ctlint.v1.Finding
+	(*LintResponse)(nil),              // 6: ctlint.v1.LintResponse
+	(*LintBatchRequest)(nil),          // 7: ctlint.v1.LintBatchRequest
+	(*LintBatchResponse)(nil),         // 8: ctlint.v1.LintBatchResponse
+}
+var file_ctlint_proto_depIdxs = []int32{
+	0,  // 0: ctlint.v1.LintCertificateRequest.policy_group:type_name -> ctlint.v1.PolicyGroup
+	0,  // 1: ctlint.v1.LintPrecertificateRequest.policy_group:type_name -> ctlint.v1.PolicyGroup
+	0,  // 2: ctlint.v1.LintSCTsRequest.policy_group:type_name -> ctlint.v1.PolicyGroup
+	1,  // 3: ctlint.v1.Finding.severity:type_name -> ctlint.v1.Severity
+	5,  // 4: ctlint.v1.LintResponse.findings:type_name -> ctlint.v1.Finding
+	2,  // 5: ctlint.v1.LintBatchRequest.certificate:type_name -> ctlint.v1.LintCertificateRequest
+	3,  // 6: ctlint.v1.LintBatchRequest.precertificate:type_name -> ctlint.v1.LintPrecertificateRequest
+	4,  // 7: ctlint.v1.LintBatchRequest.scts:type_name -> ctlint.v1.LintSCTsRequest
+	6,  // 8: ctlint.v1.LintBatchResponse.response:type_name -> ctlint.v1.LintResponse
+	2,  // 9: ctlint.v1.CTLint.LintCertificate:input_type -> ctlint.v1.LintCertificateRequest
+	3,  // 10: ctlint.v1.CTLint.LintPrecertificate:input_type -> ctlint.v1.LintPrecertificateRequest
+	4,  // 11: ctlint.v1.CTLint.LintSCTs:input_type -> ctlint.v1.LintSCTsRequest
+	7,  // 12: ctlint.v1.CTLint.LintBatch:input_type -> ctlint.v1.LintBatchRequest
+	6,  // 13: ctlint.v1.CTLint.LintCertificate:output_type -> ctlint.v1.LintResponse
+	6,  // 14: ctlint.v1.CTLint.LintPrecertificate:output_type -> ctlint.v1.LintResponse
+	6,  // 15: ctlint.v1.CTLint.LintSCTs:output_type -> ctlint.v1.LintResponse
+	8,  // 16: ctlint.v1.CTLint.LintBatch:output_type -> ctlint.v1.LintBatchResponse
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_ctlint_proto_init() }
+func file_ctlint_proto_init() {
+	if File_ctlint_proto != nil {
+		return
+	}
+	file_ctlint_proto_msgTypes[5].OneofWrappers = []any{
+		(*LintBatchRequest_Certificate)(nil),
+		(*LintBatchRequest_Precertificate)(nil),
+		(*LintBatchRequest_Scts)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ctlint_proto_rawDesc), len(file_ctlint_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ctlint_proto_goTypes,
+		DependencyIndexes: file_ctlint_proto_depIdxs,
+		EnumInfos:         file_ctlint_proto_enumTypes,
+		MessageInfos:      file_ctlint_proto_msgTypes,
+	}.Build()
+	File_ctlint_proto = out.File
+	file_ctlint_proto_goTypes = nil
+	file_ctlint_proto_depIdxs = nil
+}
@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: ctlint.proto
+
+package ctlintpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CTLint_LintCertificate_FullMethodName    = "/ctlint.v1.CTLint/LintCertificate"
+	CTLint_LintPrecertificate_FullMethodName = "/ctlint.v1.CTLint/LintPrecertificate"
+	CTLint_LintSCTs_FullMethodName           = "/ctlint.v1.CTLint/LintSCTs"
+	CTLint_LintBatch_FullMethodName          = "/ctlint.v1.CTLint/LintBatch"
+)
+
+// CTLintClient is the client API for CTLint service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CTLint lints certificates, precertificates and SCTs for CT compliance.
+type CTLintClient interface {
+	// LintCertificate checks a certificate's embedded SCTs against the applicable CT Policies.
+	LintCertificate(ctx context.Context, in *LintCertificateRequest, opts ...grpc.CallOption) (*LintResponse, error)
+	// LintPrecertificate checks a precertificate, and (if the issuer certificate is supplied) reports the issuer_key_hash that logs should use for it.
+	LintPrecertificate(ctx context.Context, in *LintPrecertificateRequest, opts ...grpc.CallOption) (*LintResponse, error)
+	// LintSCTs checks SCTs that were obtained for a precertificate, as if they were embedded in the corresponding final certificate.
+	LintSCTs(ctx context.Context, in *LintSCTsRequest, opts ...grpc.CallOption) (*LintResponse, error)
+	// LintBatch lints a stream of requests, returning one response for each request.
+	LintBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LintBatchRequest, LintBatchResponse], error)
+}
+
+type cTLintClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCTLintClient(cc grpc.ClientConnInterface) CTLintClient {
+	return &cTLintClient{cc}
+}
+
+func (c *cTLintClient) LintCertificate(ctx context.Context, in *LintCertificateRequest, opts ...grpc.CallOption) (*LintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LintResponse)
+	err := c.cc.Invoke(ctx, CTLint_LintCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cTLintClient) LintPrecertificate(ctx context.Context, in *LintPrecertificateRequest, opts ...grpc.CallOption) (*LintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LintResponse)
+	err := c.cc.Invoke(ctx, CTLint_LintPrecertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cTLintClient) LintSCTs(ctx context.Context, in *LintSCTsRequest, opts ...grpc.CallOption) (*LintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LintResponse)
+	err := c.cc.Invoke(ctx, CTLint_LintSCTs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cTLintClient) LintBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LintBatchRequest, LintBatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CTLint_ServiceDesc.Streams[0], CTLint_LintBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LintBatchRequest, LintBatchResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CTLint_LintBatchClient = grpc.BidiStreamingClient[LintBatchRequest, LintBatchResponse]
+
+// CTLintServer is the server API for CTLint service.
+// All implementations must embed UnimplementedCTLintServer
+// for forward compatibility.
+//
+// CTLint lints certificates, precertificates and SCTs for CT compliance.
+type CTLintServer interface {
+	// LintCertificate checks a certificate's embedded SCTs against the applicable CT Policies.
+	LintCertificate(context.Context, *LintCertificateRequest) (*LintResponse, error)
+	// LintPrecertificate checks a precertificate, and (if the issuer certificate is supplied) reports the issuer_key_hash that logs should use for it.
+	LintPrecertificate(context.Context, *LintPrecertificateRequest) (*LintResponse, error)
+	// LintSCTs checks SCTs that were obtained for a precertificate, as if they were embedded in the corresponding final certificate.
+	LintSCTs(context.Context, *LintSCTsRequest) (*LintResponse, error)
+	// LintBatch lints a stream of requests, returning one response for each request.
+	LintBatch(grpc.BidiStreamingServer[LintBatchRequest, LintBatchResponse]) error
+	mustEmbedUnimplementedCTLintServer()
+}
+
+// UnimplementedCTLintServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCTLintServer struct{}
+
+func (UnimplementedCTLintServer) LintCertificate(context.Context, *LintCertificateRequest) (*LintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LintCertificate not implemented")
+}
+func (UnimplementedCTLintServer) LintPrecertificate(context.Context, *LintPrecertificateRequest) (*LintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LintPrecertificate not implemented")
+}
+func (UnimplementedCTLintServer) LintSCTs(context.Context, *LintSCTsRequest) (*LintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LintSCTs not implemented")
+}
+func (UnimplementedCTLintServer) LintBatch(grpc.BidiStreamingServer[LintBatchRequest, LintBatchResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method LintBatch not implemented")
+}
+func (UnimplementedCTLintServer) mustEmbedUnimplementedCTLintServer() {}
+func (UnimplementedCTLintServer) testEmbeddedByValue()                {}
+
+// UnsafeCTLintServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CTLintServer will
+// result in compilation errors.
+type UnsafeCTLintServer interface {
+	mustEmbedUnimplementedCTLintServer()
+}
+
+func RegisterCTLintServer(s grpc.ServiceRegistrar, srv CTLintServer) {
+	// If the following call pancis, it indicates UnimplementedCTLintServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CTLint_ServiceDesc, srv)
+}
+
+func _CTLint_LintCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CTLintServer).LintCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CTLint_LintCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CTLintServer).LintCertificate(ctx, req.(*LintCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CTLint_LintPrecertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintPrecertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CTLintServer).LintPrecertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CTLint_LintPrecertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CTLintServer).LintPrecertificate(ctx, req.(*LintPrecertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CTLint_LintSCTs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintSCTsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CTLintServer).LintSCTs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CTLint_LintSCTs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CTLintServer).LintSCTs(ctx, req.(*LintSCTsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CTLint_LintBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CTLintServer).LintBatch(&grpc.GenericServerStream[LintBatchRequest, LintBatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CTLint_LintBatchServer = grpc.BidiStreamingServer[LintBatchRequest, LintBatchResponse]
+
+// CTLint_ServiceDesc is the grpc.ServiceDesc for CTLint service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CTLint_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ctlint.v1.CTLint",
+	HandlerType: (*CTLintServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LintCertificate",
+			Handler:    _CTLint_LintCertificate_Handler,
+		},
+		{
+			MethodName: "LintPrecertificate",
+			Handler:    _CTLint_LintPrecertificate_Handler,
+		},
+		{
+			MethodName: "LintSCTs",
+			Handler:    _CTLint_LintSCTs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LintBatch",
+			Handler:       _CTLint_LintBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ctlint.proto",
+}
@@ -0,0 +1,4 @@
+// Package ctlintpb contains the generated protocol buffer and gRPC code for ctlint's gRPC linting API, which is defined in ctlint.proto.
+package ctlintpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ctlint.proto
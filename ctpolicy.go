@@ -1,14 +1,13 @@
 package ctlint
 
 import (
-	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/crtsh/ccadb_data"
-	"github.com/crtsh/ctloglists"
 	ctgo "github.com/google/certificate-transparency-go"
 	"github.com/google/certificate-transparency-go/loglist3"
 	"github.com/google/certificate-transparency-go/x509"
@@ -16,27 +15,41 @@ import (
 
 var SC62EffectiveDate = time.Date(2023, time.September, 15, 0, 0, 0, 0, time.UTC)
 
-func checkSCTListCompliance(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup, sha256IssuerSPKI *[sha256.Size]byte, scts []*ctgo.SignedCertificateTimestamp) []string {
-	var findings []string
-
+func checkSCTListCompliance(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, scts []*ctgo.SignedCertificateTimestamp) []string {
 	tbsCert, err := x509.RemoveSCTList(cert.RawTBSCertificate)
 	if err != nil {
 		return []string{"E: Cannot remove SCT List extension to derive TBSCertificate"}
 	}
 
+	return checkSCTListComplianceWithTBSCertificate(cert, tbsCert, ctPolicyGroup, sha256IssuerSPKI, issuerResolver, scts)
+}
+
+// checkSCTListComplianceWithTBSCertificate is like checkSCTListCompliance, except that the SCTs are verified over tbsCert, which has already been derived (e.g., from a precertificate).
+func checkSCTListComplianceWithTBSCertificate(cert *x509.Certificate, tbsCert []byte, ctPolicyGroup CTPolicyGroup, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, scts []*ctgo.SignedCertificateTimestamp) []string {
+	var findings []string
+
+	snapshot := CurrentLogListSnapshot()
+	findings = append(findings, fmt.Sprintf("I: SCTs checked against log list snapshot version %d", snapshot.Version()))
+
+	logs := snapshot.resolveSCTLogs(scts)
 	latestSCTTimestamp := uint64(0)
-	for _, sct := range scts {
+	for i, sct := range scts {
 		if sha256IssuerSPKI == nil {
-			if encoded, found := ccadb_data.GetIssuerSPKISHA256ByKeyIdentifier(base64.StdEncoding.EncodeToString(cert.AuthorityKeyId)); found {
-				sha256IssuerSPKI = &encoded
-			} else {
-				return []string{"W: Cannot verify SCT signature without issuer SPKI, which could not be found in the available CCADB data"}
+			var resolverFindings []string
+			if sha256IssuerSPKI, resolverFindings = issuerResolver.ResolveIssuerSPKISHA256(cert); sha256IssuerSPKI == nil {
+				return resolverFindings
 			}
+			findings = append(findings, resolverFindings...)
 		}
 
-		findings = append(findings, verifySCT(tbsCert, sha256IssuerSPKI, sct)...)
+		findings = append(findings, verifySCT(tbsCert, sha256IssuerSPKI, sct, logs[i], snapshot)...)
+		findings = append(findings, checkLogOperatorAttribution(logs[i])...)
+		switch ctPolicyGroup {
+		case ServerAuthenticationCertificate, MarkCertificate:
+			findings = append(findings, checkSCTLogPurpose(logs[i])...)
+		}
 
-		if ti := ctloglists.TemporalIntervalMap[sct.LogID.KeyID]; ti != nil {
+		if ti := snapshot.temporalIntervals[sct.LogID.KeyID]; ti != nil {
 			if cert.NotAfter.Before(ti.StartInclusive) || !cert.NotAfter.Before(ti.EndExclusive) {
 				findings = append(findings, "E: Certificate expires outside log's temporal interval")
 			}
@@ -58,11 +71,9 @@ func checkSCTListCompliance(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup,
 	} else {
 		switch ctPolicyGroup {
 		case ServerAuthenticationCertificate:
-			findings = append(findings, checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctloglists.GstaticV3All, "Chrome")...)
-			findings = append(findings, checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctloglists.AppleCurrent, "Apple")...)
-			findings = append(findings, checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctloglists.MozillaV3Known, "Mozilla")...)
+			findings = append(findings, checkSCTListComplianceWithApplicableServerAuthenticationCTPolicies(cert, sha256IssuerSPKI, scts, logs, snapshot)...)
 		case MarkCertificate:
-			findings = append(findings, checkSCTListComplianceWithMarkCertificateGuidelines(scts, ctloglists.BimiV3Approved)...)
+			findings = append(findings, checkSCTListComplianceWithMarkCertificateGuidelines(inLogList(logs, "BIMI"))...)
 		default:
 			findings = append(findings, "I: SCT list has no applicable CT Policies")
 		}
@@ -71,34 +82,93 @@ func checkSCTListCompliance(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup,
 	return findings
 }
 
-func findLogByKeyHash(keyHash [sha256.Size]byte, logList *loglist3.LogList) (*loglist3.Log, string, bool) {
-	for _, operator := range logList.Operators {
-		for _, log := range operator.Logs {
-			if bytes.Equal(log.LogID, keyHash[:]) {
-				return log, operator.Name, true
+// serverAuthenticationCTPolicy is a root program's Server Authentication CT Policy, which is evaluated against the root program's log list.
+type serverAuthenticationCTPolicy struct {
+	logListIndex *logListIndex
+	name         string
+}
+
+// serverAuthenticationCTPolicies returns the Server Authentication CT Policies, each with its log list in the snapshot.
+func (snapshot *LogListSnapshot) serverAuthenticationCTPolicies() []serverAuthenticationCTPolicy {
+	return []serverAuthenticationCTPolicy{
+		{snapshot.chrome, "Chrome"},
+		{snapshot.apple, "Apple"},
+		{snapshot.mozilla, "Mozilla"},
+	}
+}
+
+// appliesTo reports whether the CT Policy applies to a certificate whose issuing CA is trusted by rootPrograms (see getIssuerRootPrograms).  Every CT Policy applies if the issuing CA is unknown.
+func (ctPolicy serverAuthenticationCTPolicy) appliesTo(rootPrograms []string, isIssuerKnown bool) bool {
+	return !isIssuerKnown || slices.Contains(rootPrograms, ctPolicy.name)
+}
+
+// applicableServerAuthenticationCTPoliciesForEmbeddedSCTs returns a certificate's embedded SCTs, along with the Server Authentication CT Policies that apply to it.  If sha256IssuerSPKI is specified and the issuing CA is in the available CCADB data, only the CT Policies of the root programs that trust the issuing hierarchy apply.
+// If there is nothing to evaluate, because the certificate is not provided, has no (parseable) embedded SCTs, or is not a Server Authentication certificate, the findings explain why.
+func applicableServerAuthenticationCTPoliciesForEmbeddedSCTs(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, snapshot *LogListSnapshot) ([]*ctgo.SignedCertificateTimestamp, []serverAuthenticationCTPolicy, []string) {
+	if cert == nil {
+		return nil, nil, []string{"E: Certificate not provided"}
+	}
+
+	var scts []*ctgo.SignedCertificateTimestamp
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(x509.OIDExtensionCTSCT) {
+			var findings []string
+			if scts, findings = parseSCTListExtension(ext); len(findings) > 0 {
+				return nil, nil, findings
 			}
 		}
 	}
+	if len(scts) == 0 {
+		return nil, nil, []string{"I: Certificate contains no embedded SCTs"}
+	}
 
-	for _, operator := range logList.Operators {
-		for _, tiledLog := range operator.TiledLogs {
-			if bytes.Equal(tiledLog.LogID, keyHash[:]) {
-				return &loglist3.Log{
-					State:            tiledLog.State,
-					TemporalInterval: tiledLog.TemporalInterval,
-					Description:      tiledLog.Description,
-				}, operator.Name, false
-			}
+	if policyGroup, _ := DetectPolicyGroup(cert); policyGroup != ServerAuthenticationCertificate {
+		return nil, nil, []string{fmt.Sprintf("I: No Server Authentication CT Policies apply to this %s", policyGroup)}
+	}
+
+	var ctPolicies []serverAuthenticationCTPolicy
+	rootPrograms, isIssuerKnown := getIssuerRootPrograms(sha256IssuerSPKI)
+	for _, ctPolicy := range snapshot.serverAuthenticationCTPolicies() {
+		if ctPolicy.appliesTo(rootPrograms, isIssuerKnown) {
+			ctPolicies = append(ctPolicies, ctPolicy)
 		}
 	}
 
-	return nil, "", false
+	return scts, ctPolicies, nil
 }
 
-func checkSCTListComplianceWithMarkCertificateGuidelines(scts []*ctgo.SignedCertificateTimestamp, logList *loglist3.LogList) []string {
+// checkSCTListComplianceWithApplicableServerAuthenticationCTPolicies only evaluates the CT Policies of the root programs that trust the issuing hierarchy.
+// If the issuing CA is absent from the available CCADB data, the hierarchy is presumably not publicly-trusted, so the CT Policies are evaluated but their findings are only informational.
+// If no CCADB data is available, every CT Policy is evaluated.
+func checkSCTListComplianceWithApplicableServerAuthenticationCTPolicies(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, scts []*ctgo.SignedCertificateTimestamp, logs []*resolvedLog, snapshot *LogListSnapshot) []string {
+	var findings []string
+
+	rootPrograms, isIssuerKnown := getIssuerRootPrograms(sha256IssuerSPKI)
+	isInformational := !isIssuerKnown && len(issuerRootProgramsMap) > 0
+	if isInformational {
+		findings = append(findings, "N: Issuing CA is not in the available CCADB data, so CT Policy findings are informational only")
+	}
+
+	var applicableCTPolicyNames []string
+	for _, ctPolicy := range snapshot.serverAuthenticationCTPolicies() {
+		if !ctPolicy.appliesTo(rootPrograms, isIssuerKnown) {
+			findings = append(findings, fmt.Sprintf("I: %s CT Policy does not apply, because the issuing hierarchy is not trusted by %s", ctPolicy.name, ctPolicy.name))
+			continue
+		}
+
+		policyFindings, _ := checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, inLogList(logs, ctPolicy.name), ctPolicy.logListIndex, ctPolicy.name, time.Now(), isInformational)
+		findings = append(findings, policyFindings...)
+		applicableCTPolicyNames = append(applicableCTPolicyNames, ctPolicy.name)
+	}
+
+	return append(findings, checkSCTTimestampsAgainstLogLifecycles(scts, logs, applicableCTPolicyNames, isInformational)...)
+}
+
+// ctLogs are the logs that issued the SCTs, as they appear in the BIMI log list.
+func checkSCTListComplianceWithMarkCertificateGuidelines(ctLogs []*indexedLog) []string {
 	// Mark Certificate Guidelines: "Before issuance of a Mark Certificate, the CA SHALL log the Mark Certificate pre-certificate (including all the data included in the Subject field of the certificate plus the Mark Representation) to one or more public CT logs. The list of CT logs that are acceptable for the fulfillment of this requirement is found in Appendix F.
-	for _, sct := range scts {
-		if ctLog, _, _ := findLogByKeyHash(sct.LogID.KeyID, logList); ctLog != nil && ctLog.State != nil {
+	for _, ctLog := range ctLogs {
+		if ctLog != nil && ctLog.State != nil {
 			if ctLog.State.Usable != nil && !ctLog.State.Usable.Timestamp.After(time.Now()) {
 				return nil
 			}
@@ -108,42 +178,58 @@ func checkSCTListComplianceWithMarkCertificateGuidelines(scts []*ctgo.SignedCert
 	return []string{"E: SCT list contains no SCTs from logs currently approved by the Mark Certificate Guidelines"}
 }
 
-func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certificate, scts []*ctgo.SignedCertificateTimestamp, logList *loglist3.LogList, ctPolicyName string) []string {
+// checkSCTListComplianceWithServerAuthenticationCTPolicy evaluates a CT Policy as it applies at the specified time, according to the log states in logListIndex, and reports whether the SCT list complies with it.
+// ctLogs are the logs in logListIndex that issued the SCTs (see logListIndex.findLogs), which are resolved by the caller so that a CT Policy can be evaluated repeatedly without resolving them again.
+// If isInformational is true, the CT Policy might not apply to the certificate, so any warnings and errors are reported as informational findings instead.
+func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certificate, scts []*ctgo.SignedCertificateTimestamp, ctLogs []*indexedLog, logListIndex *logListIndex, ctPolicyName string, at time.Time, isInformational bool) ([]string, bool) {
 	var findings []string
+	isCompliant := true
+	warningSeverity := ctPolicyFindingSeverity("W", isInformational)
 
 	// Chrome CT Policy: "Chrome will enforce CT so long as the log_list_timestamp of the freshest version of the log list Chrome stores is within the past 70 days (10 weeks), and uses a log list format that Chrome understands."
 	// Mozilla CT Policy: "This information has a 10 week expiration time. That is, if 10 weeks have passed since the information has been updated (typically by updating Firefox itself), the implementation will no longer enforce certificate transparency."
 	switch ctPolicyName {
 	case "Chrome", "Mozilla":
-		if logList.LogListTimestamp.Add(70 * 24 * time.Hour).Before(time.Now()) {
-			findings = append(findings, fmt.Sprintf("F: The available %s log list is older than 70 days: Update ctlint!", ctPolicyName))
+		if logListIndex.logList.LogListTimestamp.Add(70 * 24 * time.Hour).Before(at) {
+			findings = append(findings, fmt.Sprintf("F: The available %s log list is older than 70 days: Update ctlint, or run \"ctlint update-loglists\"!", ctPolicyName))
 		}
 	}
 
-	var currentlyApprovedLogs, onceApprovedLogs []*loglist3.Log
-	previousOperatorName := ""
+	if logListIndex.source != "" {
+		findings = append(findings, fmt.Sprintf("I: %s CT Policy evaluated using the log list from %s", ctPolicyName, logListIndex.source))
+	}
+
+	var currentlyApprovedLogs, onceApprovedLogs []*indexedLog
+	previousOperatorID := ""
 	atLeastTwoOperators := false
 	nSCTsFromQualifiedLogs := 0
 	nSCTsFromRFC6962Logs := 0
-	for _, sct := range scts {
-		if ctLog, logOperatorName, isRFC6962Log := findLogByKeyHash(sct.LogID.KeyID, logList); ctLog != nil && ctLog.State != nil {
-			if (ctLog.State.Usable != nil && !ctLog.State.Usable.Timestamp.After(time.Now())) || ctLog.State.ReadOnly != nil {
+	for i, sct := range scts {
+		if ctLog := ctLogs[i]; ctLog == nil {
+			findings = append(findings, fmt.Sprintf("N: SCT from log %s does not count towards the %s CT Policy, because the log is not in the %s log list", base64.StdEncoding.EncodeToString(sct.LogID.KeyID[:]), ctPolicyName, ctPolicyName))
+		} else if ctLog.State == nil {
+			findings = append(findings, fmt.Sprintf("N: SCT from %s does not count towards the %s CT Policy, because the log has no state in the %s log list", ctLog.Description, ctPolicyName, ctPolicyName))
+		} else {
+			// A log whose retirement is scheduled for a later time remains approved until then.
+			if (ctLog.State.Usable != nil && !ctLog.State.Usable.Timestamp.After(at)) || ctLog.State.ReadOnly != nil || (ctLog.State.Retired != nil && ctLog.State.Retired.Timestamp.After(at)) {
 				currentlyApprovedLogs = append(currentlyApprovedLogs, ctLog)
-			} else if ctLog.State.Qualified != nil && !ctLog.State.Qualified.Timestamp.After(time.Now()) {
+			} else if ctLog.State.Qualified != nil && !ctLog.State.Qualified.Timestamp.After(at) {
 				nSCTsFromQualifiedLogs++
 				currentlyApprovedLogs = append(currentlyApprovedLogs, ctLog)
 			} else if ctLog.State.Retired != nil && ctLog.State.Retired.Timestamp.After(time.UnixMilli(int64(sct.Timestamp))) {
 				onceApprovedLogs = append(onceApprovedLogs, ctLog)
 			} else {
+				findings = append(findings, fmt.Sprintf("N: SCT from %s does not count towards the %s CT Policy, because the log is %s in the %s log list", ctLog.Description, ctPolicyName, describeLogState(ctLog.State, at), ctPolicyName))
 				continue
 			}
 
-			if previousOperatorName != "" && logOperatorName != previousOperatorName {
+			// Operators are compared by canonical identifier, so that an operator that appears under more than one name (e.g., after being renamed) is not counted as two distinct operators.
+			if previousOperatorID != "" && ctLog.operatorID != previousOperatorID {
 				atLeastTwoOperators = true
 			}
-			previousOperatorName = logOperatorName
+			previousOperatorID = ctLog.operatorID
 
-			if isRFC6962Log {
+			if ctLog.isRFC6962Log {
 				nSCTsFromRFC6962Logs++
 			}
 		}
@@ -153,7 +239,8 @@ func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certifica
 	// Apple CT Policy: "At least one embedded SCT from a currently approved log and"
 	// Mozilla CT Policy: "At least 1 of those SCTs must be from a log that was Admissible at the time of verification"
 	if len(currentlyApprovedLogs) < 1 {
-		findings = append(findings, fmt.Sprintf("W: SCT list contains no SCTs from logs currently approved by the %s CT Policy", ctPolicyName))
+		findings = append(findings, fmt.Sprintf("%s: SCT list contains no SCTs from logs currently approved by the %s CT Policy", warningSeverity, ctPolicyName))
+		isCompliant = false
 	}
 
 	// Chrome CT Policy: "2. There are Embedded SCTs from at least N distinct CT logs that were Qualified, Usable, ReadOnly, or Retired at the time of check...
@@ -166,13 +253,14 @@ func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certifica
 		nApprovedSCTsRequired++
 	}
 	if len(currentlyApprovedLogs)+len(onceApprovedLogs) < nApprovedSCTsRequired {
-		findings = append(findings, fmt.Sprintf("W: SCT list contains fewer approved SCTs than required by the %s CT Policy", ctPolicyName))
+		findings = append(findings, fmt.Sprintf("%s: SCT list contains fewer approved SCTs than required by the %s CT Policy", warningSeverity, ctPolicyName))
+		isCompliant = false
 	} else if len(currentlyApprovedLogs)+len(onceApprovedLogs)-nSCTsFromQualifiedLogs < nApprovedSCTsRequired {
 		switch ctPolicyName {
 		case "Mozilla":
-			findings = append(findings, fmt.Sprintf("W: SCT list satisfies the %s CT Policy using at least 1 SCT from an Admissible log that is not yet broadly usable", ctPolicyName))
+			findings = append(findings, fmt.Sprintf("%s: SCT list satisfies the %s CT Policy using at least 1 SCT from an Admissible log that is not yet broadly usable", warningSeverity, ctPolicyName))
 		default:
-			findings = append(findings, fmt.Sprintf("W: SCT list satisfies the %s CT Policy using at least 1 SCT from a Qualified log that is not yet Usable", ctPolicyName))
+			findings = append(findings, fmt.Sprintf("%s: SCT list satisfies the %s CT Policy using at least 1 SCT from a Qualified log that is not yet Usable", warningSeverity, ctPolicyName))
 		}
 	}
 
@@ -180,7 +268,8 @@ func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certifica
 	// Apple CT Policy: "Maximum # of SCTs per log operator which count towards the SCT requirement: '180 days or less' => 1; '181 to 398 days' => 2"
 	// Mozilla CT Policy: "Among those SCTs, at least 2 must be from distinct log operators."
 	if !atLeastTwoOperators {
-		findings = append(findings, fmt.Sprintf("W: SCT list contains SCTs from fewer log operators than required by the %s CT Policy", ctPolicyName))
+		findings = append(findings, fmt.Sprintf("%s: SCT list contains SCTs from fewer log operators than required by the %s CT Policy", warningSeverity, ctPolicyName))
+		isCompliant = false
 	}
 
 	// Chrome CT Policy: "4. Before April 15, 2026: Among the SCTs satisfying requirement 2, at least one SCT must be issued from a log recognized by Chrome as being RFC6962-compliant."
@@ -189,17 +278,127 @@ func checkSCTListComplianceWithServerAuthenticationCTPolicy(cert *x509.Certifica
 		var enforceOneRFC6962LogPolicy bool
 		switch ctPolicyName {
 		case "Chrome":
-			enforceOneRFC6962LogPolicy = time.Now().Before(time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC))
+			enforceOneRFC6962LogPolicy = at.Before(time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC))
 		case "Apple":
 			enforceOneRFC6962LogPolicy = true
 		case "Mozilla":
-			enforceOneRFC6962LogPolicy = time.Now().Before(time.Date(2026, 2, 10, 9, 45, 58, 0, time.UTC)) // Push timestamp of https://hg-edge.mozilla.org/mozilla-central/rev/afcac3008cbb plus 70 days.
+			enforceOneRFC6962LogPolicy = at.Before(time.Date(2026, 2, 10, 9, 45, 58, 0, time.UTC)) // Push timestamp of https://hg-edge.mozilla.org/mozilla-central/rev/afcac3008cbb plus 70 days.
 		}
 
 		if enforceOneRFC6962LogPolicy {
-			findings = append(findings, fmt.Sprintf("W: SCT list contains fewer SCTs from RFC6962-compliant logs than required by the %s CT Policy", ctPolicyName))
+			findings = append(findings, fmt.Sprintf("%s: SCT list contains fewer SCTs from RFC6962-compliant logs than required by the %s CT Policy", warningSeverity, ctPolicyName))
+			isCompliant = false
+		}
+	}
+
+	return findings, isCompliant
+}
+
+// checkSCTTimestampsAgainstLogLifecycles checks that each SCT's timestamp falls within the operational window of the log that issued it, according to the log's state in the log list of each of the named CT Policies.  Each finding is reported once per SCT, naming every log list in which it arises, rather than once per CT Policy.
+func checkSCTTimestampsAgainstLogLifecycles(scts []*ctgo.SignedCertificateTimestamp, logs []*resolvedLog, ctPolicyNames []string, isInformational bool) []string {
+	// Each state's timestamp records when the root program changed the log's state, which need not coincide with when the log started or stopped issuing SCTs, and an SCT that a log issued after it was ReadOnly, Retired or Rejected already does not count towards CT Policy compliance.  An SCT outside the log's operational window is therefore implausible rather than proven invalid, so these findings are all warnings.
+	warningSeverity := ctPolicyFindingSeverity("W", isInformational)
+
+	var findings []string
+	for i, sct := range scts {
+		var formats, descriptions []string
+		logListNames := make(map[string][]string)
+		for _, ctPolicyName := range ctPolicyNames {
+			ctLog := logs[i].inLogList(ctPolicyName)
+			if ctLog == nil || ctLog.State == nil {
+				continue
+			} else if format := logLifecycleFindingFormat(sct, ctLog); format != "" {
+				if _, found := logListNames[format]; !found {
+					formats, descriptions = append(formats, format), append(descriptions, ctLog.Description)
+				}
+				logListNames[format] = append(logListNames[format], ctPolicyName)
+			}
+		}
+
+		for j, format := range formats {
+			findings = append(findings, fmt.Sprintf(format, warningSeverity, descriptions[j], describeLogLists(logListNames[format])))
 		}
 	}
 
 	return findings
 }
+
+// logLifecycleFindingFormat returns the format of the finding, if any, that reports an SCT's timestamp as outside the operational window of the log that issued it, according to the log's state (and the timestamp at which it entered that state) in a log list.
+// A log list only records a log's current state, so an SCT can only be checked against the start of the log's lifecycle while the log is Pending: a later state's timestamp (e.g., Usable) postdates the period in which the log was already legitimately issuing SCTs, and a temporal interval constrains the certificates that a log accepts, not when it issues SCTs.
+func logLifecycleFindingFormat(sct *ctgo.SignedCertificateTimestamp, ctLog *indexedLog) string {
+	sctTimestamp := time.UnixMilli(int64(sct.Timestamp))
+	switch {
+	case ctLog.State.Pending != nil && sctTimestamp.Before(ctLog.State.Pending.Timestamp):
+		return "%s: SCT from %s has a timestamp before the log became Pending in the %s, so the log's key may not yet have existed"
+	case ctLog.State.ReadOnly != nil && !sctTimestamp.Before(ctLog.State.ReadOnly.Timestamp):
+		return "%s: SCT from %s has a timestamp after the log became ReadOnly in the %s"
+	case ctLog.State.Retired != nil && !sctTimestamp.Before(ctLog.State.Retired.Timestamp):
+		return "%s: SCT from %s has a timestamp after the log was Retired in the %s"
+	case ctLog.State.Rejected != nil && !sctTimestamp.Before(ctLog.State.Rejected.Timestamp):
+		return "%s: SCT from %s has a timestamp after the log was Rejected in the %s"
+	}
+
+	return ""
+}
+
+// describeLogLists describes the named log lists, such as "Chrome and Apple log lists".
+func describeLogLists(names []string) string {
+	if len(names) == 1 {
+		return names[0] + " log list"
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1] + " log lists"
+}
+
+// ctPolicyFindingSeverity returns the severity of a CT Policy finding, which is only informational if the CT Policy might not apply to the certificate.
+func ctPolicyFindingSeverity(severity string, isInformational bool) string {
+	if isInformational {
+		return "I"
+	}
+	return severity
+}
+
+// describeLogState describes a log's state in a log list, and when it entered (or, as of the specified time, will enter) that state.
+func describeLogState(state *loglist3.LogStates, at time.Time) string {
+	var name string
+	var timestamp time.Time
+	switch {
+	case state.Pending != nil:
+		name, timestamp = "Pending", state.Pending.Timestamp
+	case state.Qualified != nil:
+		name, timestamp = "Qualified", state.Qualified.Timestamp
+	case state.Usable != nil:
+		name, timestamp = "Usable", state.Usable.Timestamp
+	case state.ReadOnly != nil:
+		name, timestamp = "ReadOnly", state.ReadOnly.Timestamp
+	case state.Retired != nil:
+		name, timestamp = "Retired", state.Retired.Timestamp
+	case state.Rejected != nil:
+		name, timestamp = "Rejected", state.Rejected.Timestamp
+	default:
+		return "in an undefined state"
+	}
+
+	if timestamp.After(at) {
+		return fmt.Sprintf("not %s until %s", name, timestamp.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s since %s", name, timestamp.UTC().Format(time.RFC3339))
+}
+
+// checkSCTLogPurpose checks that an SCT embedded in a production certificate is not from a test log (i.e., one whose log_type is "test" in any of the log lists), nor from a log that mimics a production log.
+func checkSCTLogPurpose(log *resolvedLog) []string {
+	isInProductionLogList := false
+	for _, ctLog := range []*indexedLog{log.crtsh, log.chrome, log.apple, log.mozilla, log.bimi} {
+		if ctLog != nil {
+			if ctLog.Type == "test" {
+				return []string{fmt.Sprintf("E: SCT from %s, which is a test log, is embedded in a production certificate", log.description)}
+			}
+			isInProductionLogList = true
+		}
+	}
+
+	if !isInProductionLogList && log.mimics != nil {
+		return []string{fmt.Sprintf("E: SCT from %s, which mimics a production log, is embedded in a production certificate", log.description)}
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package ctlint
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	encasn1 "encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/loglist3"
+	"github.com/google/certificate-transparency-go/tls"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// loadTestLogList loads an Apple log list containing a new Usable log for each of the specified operators, and returns the logs' private keys.  The previous log lists are restored when the test finishes.
+func loadTestLogList(tb testing.TB, operatorNames ...string) []*ecdsa.PrivateKey {
+	tb.Helper()
+
+	var keys []*ecdsa.PrivateKey
+	var operators []any
+	for i, operatorName := range operatorNames {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		spki, err := stdx509.MarshalPKIXPublicKey(&key.PublicKey)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		logID := sha256.Sum256(spki)
+		keys = append(keys, key)
+		operators = append(operators, map[string]any{
+			"name":  operatorName,
+			"email": []string{"ct@example.com"},
+			"logs": []any{map[string]any{
+				"description": fmt.Sprintf("%s 'Test%d' log", operatorName, i),
+				"log_id":      logID[:],
+				"key":         spki,
+				"url":         fmt.Sprintf("https://ct%d.example.com/", i),
+				"mmd":         86400,
+				"state":       map[string]any{"usable": map[string]string{"timestamp": time.Now().Add(-365 * 24 * time.Hour).UTC().Format(time.RFC3339)}},
+			}},
+		})
+	}
+
+	data, err := json.Marshal(map[string]any{"version": "1.0", "log_list_timestamp": time.Now().UTC().Format(time.RFC3339), "operators": operators})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	filename := filepath.Join(tb.TempDir(), "current_log_list.json")
+	if err = os.WriteFile(filename, data, 0644); err != nil {
+		tb.Fatal(err)
+	}
+	if _, err = ReloadLogLists(LogListFiles{AppleLogList: filename}); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { ReloadLogLists() })
+	return keys
+}
+
+// newTestCertificateWithValidSCTs issues a certificate that embeds an SCT, with a valid signature, from each of the specified logs, and returns the certificate and its issuer.
+func newTestCertificateWithValidSCTs(tb testing.TB, logKeys []*ecdsa.PrivateKey) (*x509.Certificate, *x509.Certificate) {
+	tb.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	caTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test CA"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(365 * 24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: stdx509.KeyUsageCertSign}
+	caDER, err := stdx509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	caCert, err := stdx509.ParseCertificate(caDER)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	// The TBSCertificate without an SCT list extension is the same as the TBSCertificate of the certificate that embeds the SCTs, without its SCT list extension.
+	template := &stdx509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.com"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(90 * 24 * time.Hour), ExtKeyUsage: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth}}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, caCert, &caKey.PublicKey, caKey)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	issuerKeyHash := sha256.Sum256(caCert.RawSubjectPublicKeyInfo)
+
+	var sctList x509.SignedCertificateTimestampList
+	for _, logKey := range logKeys {
+		spki, err := stdx509.MarshalPKIXPublicKey(&logKey.PublicKey)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		sct := ctgo.SignedCertificateTimestamp{SCTVersion: ctgo.V1, LogID: ctgo.LogID{KeyID: sha256.Sum256(spki)}, Timestamp: uint64(time.Now().Add(-time.Minute).UnixMilli())}
+		signatureInput, err := ctgo.SerializeSCTSignatureInput(sct, ctgo.LogEntry{Leaf: precertMerkleTreeLeaf(cert.RawTBSCertificate, &issuerKeyHash, &sct)})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		signature, err := tls.CreateSignature(*logKey, tls.SHA256, signatureInput)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		sct.Signature = ctgo.DigitallySigned(signature)
+
+		serialized, err := tls.Marshal(sct)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		sctList.SCTList = append(sctList.SCTList, x509.SerializedSCT{Val: serialized})
+	}
+	sctListValue, err := tls.Marshal(sctList)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	extValue, err := encasn1.Marshal(sctListValue)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	template.ExtraExtensions = []pkix.Extension{{Id: encasn1.ObjectIdentifier(x509.OIDExtensionCTSCT), Value: extValue}}
+	if der, err = stdx509.CreateCertificate(rand.Reader, template, caCert, &caKey.PublicKey, caKey); err != nil {
+		tb.Fatal(err)
+	}
+
+	if cert, err = x509.ParseCertificate(der); err != nil {
+		tb.Fatal(err)
+	}
+	issuer, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return cert, issuer
+}
+
+// trustTestIssuer records that every root program trusts issuer's hierarchy until the test finishes, so that CT Policy findings are not merely informational once the CCADB data has been generated.
+func trustTestIssuer(tb testing.TB, issuer *x509.Certificate) {
+	tb.Helper()
+
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	issuerRootProgramsMap[sha256IssuerSPKI] = []string{"Chrome", "Apple", "Mozilla"}
+	tb.Cleanup(func() { delete(issuerRootProgramsMap, sha256IssuerSPKI) })
+}
+
+func TestCheckCertificateCTPolicyCompliance(t *testing.T) {
+	logKeys := loadTestLogList(t, "Operator A", "Operator B", "Operator B")
+	cert, issuer := newTestCertificateWithValidSCTs(t, logKeys)
+	trustTestIssuer(t, issuer)
+
+	findings := CheckCertificateWithIssuer(cert, issuer)
+	nValidSignatures := 0
+	for _, finding := range findings {
+		if strings.HasPrefix(finding, "I: SCT has a valid signature") {
+			nValidSignatures++
+		} else if strings.Contains(finding, "Apple CT Policy") && !strings.HasPrefix(finding, "I: ") {
+			t.Errorf("unexpected finding: %s", finding)
+		}
+	}
+	if nValidSignatures != len(logKeys) {
+		t.Errorf("%d SCTs have valid signatures, want %d:\n%s", nValidSignatures, len(logKeys), strings.Join(findings, "\n"))
+	}
+
+	// Without a second operator, the Apple CT Policy is not satisfied.
+	logKeys = loadTestLogList(t, "Operator A", "Operator A")
+	cert, issuer = newTestCertificateWithValidSCTs(t, logKeys)
+	trustTestIssuer(t, issuer)
+	findings = CheckCertificateWithIssuer(cert, issuer)
+	if want := "W: SCT list contains SCTs from fewer log operators than required by the Apple CT Policy"; !strings.Contains(strings.Join(findings, "\n"), want) {
+		t.Errorf("missing %q in findings:\n%s", want, strings.Join(findings, "\n"))
+	}
+}
+
+func TestCheckSCTTimestampsAgainstLogLifecycles(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2025, time.January, n, 0, 0, 0, 0, time.UTC) }
+	for _, test := range []struct {
+		name             string
+		state            loglist3.LogStates
+		temporalInterval *loglist3.TemporalInterval
+		sctTimestamp     time.Time
+		want             string
+	}{
+		{"before Pending", loglist3.LogStates{Pending: &loglist3.LogState{Timestamp: day(10)}}, nil, day(5), "W: SCT from Test log has a timestamp before the log became Pending in the Chrome log list"},
+		{"Pending", loglist3.LogStates{Pending: &loglist3.LogState{Timestamp: day(10)}}, nil, day(15), ""},
+		// The log was Qualified before it became Usable, and was already issuing SCTs then.
+		{"before Usable", loglist3.LogStates{Usable: &loglist3.LogState{Timestamp: day(10)}}, nil, day(5), ""},
+		{"before Qualified", loglist3.LogStates{Qualified: &loglist3.LogState{Timestamp: day(10)}}, nil, day(5), ""},
+		// A temporal interval constrains the certificates that a log accepts, not when it issues SCTs.
+		{"before temporal interval", loglist3.LogStates{Usable: &loglist3.LogState{Timestamp: day(1)}}, &loglist3.TemporalInterval{StartInclusive: day(20), EndExclusive: day(30)}, day(5), ""},
+		{"Usable", loglist3.LogStates{Usable: &loglist3.LogState{Timestamp: day(10)}}, nil, day(15), ""},
+		{"before ReadOnly", loglist3.LogStates{ReadOnly: &loglist3.ReadOnlyLogState{LogState: loglist3.LogState{Timestamp: day(10)}}}, nil, day(5), ""},
+		{"after ReadOnly", loglist3.LogStates{ReadOnly: &loglist3.ReadOnlyLogState{LogState: loglist3.LogState{Timestamp: day(10)}}}, nil, day(15), "W: SCT from Test log has a timestamp after the log became ReadOnly in the Chrome log list"},
+		{"before Retired", loglist3.LogStates{Retired: &loglist3.LogState{Timestamp: day(10)}}, nil, day(5), ""},
+		{"after Retired", loglist3.LogStates{Retired: &loglist3.LogState{Timestamp: day(10)}}, nil, day(15), "W: SCT from Test log has a timestamp after the log was Retired in the Chrome log list"},
+		{"before Rejected", loglist3.LogStates{Rejected: &loglist3.LogState{Timestamp: day(10)}}, nil, day(5), ""},
+		{"after Rejected", loglist3.LogStates{Rejected: &loglist3.LogState{Timestamp: day(10)}}, nil, day(15), "W: SCT from Test log has a timestamp after the log was Rejected in the Chrome log list"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			log := &resolvedLog{chrome: &indexedLog{Log: &loglist3.Log{Description: "Test log", State: &test.state, TemporalInterval: test.temporalInterval}}}
+			sct := &ctgo.SignedCertificateTimestamp{Timestamp: uint64(test.sctTimestamp.UnixMilli())}
+			findings := checkSCTTimestampsAgainstLogLifecycles([]*ctgo.SignedCertificateTimestamp{sct}, []*resolvedLog{log}, []string{"Chrome", "Apple", "Mozilla"}, false)
+			if test.want == "" && len(findings) != 0 {
+				t.Errorf("unexpected findings: %v", findings)
+			} else if test.want != "" && (len(findings) != 1 || !strings.HasPrefix(findings[0], test.want)) {
+				t.Errorf("findings = %v, want %q", findings, test.want)
+			}
+		})
+	}
+
+	// A finding that arises in several log lists is reported once per SCT, rather than once per CT Policy.
+	retired := &indexedLog{Log: &loglist3.Log{Description: "Test log", State: &loglist3.LogStates{Retired: &loglist3.LogState{Timestamp: day(10)}}}}
+	rejected := &indexedLog{Log: &loglist3.Log{Description: "Test log", State: &loglist3.LogStates{Rejected: &loglist3.LogState{Timestamp: day(10)}}}}
+	log := &resolvedLog{chrome: retired, apple: rejected, mozilla: retired}
+	sct := &ctgo.SignedCertificateTimestamp{Timestamp: uint64(day(15).UnixMilli())}
+	want := []string{
+		"W: SCT from Test log has a timestamp after the log was Retired in the Chrome and Mozilla log lists",
+		"W: SCT from Test log has a timestamp after the log was Rejected in the Apple log list",
+	}
+	if findings := checkSCTTimestampsAgainstLogLifecycles([]*ctgo.SignedCertificateTimestamp{sct, sct}, []*resolvedLog{log, unknownLog}, []string{"Chrome", "Apple", "Mozilla"}, false); !slices.Equal(findings, want) {
+		t.Errorf("findings = %q, want %q", findings, want)
+	}
+}
+
+// BenchmarkCheckCertificate measures the throughput of bulk corpus linting, in which each certificate embeds SCTs from logs in the log lists.
+func BenchmarkCheckCertificate(b *testing.B) {
+	logKeys := loadTestLogList(b, "Operator A", "Operator B", "Operator C")
+	cert, issuer := newTestCertificateWithValidSCTs(b, logKeys)
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			CheckCertificate(cert, &sha256IssuerSPKI)
+		}
+	})
+}
+
+// BenchmarkCheckComplianceTimeline measures the throughput of evaluating CT Policy compliance timelines, which evaluates each CT Policy repeatedly for each certificate.
+func BenchmarkCheckComplianceTimeline(b *testing.B) {
+	logKeys := loadTestLogList(b, "Operator A", "Operator B", "Operator C")
+	cert, issuer := newTestCertificateWithValidSCTs(b, logKeys)
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			CheckComplianceTimeline(cert, &sha256IssuerSPKI)
+		}
+	})
+}
+
+// BenchmarkCheckLogEntry measures the throughput of linting log entries, as when monitoring a log or linting a log shard.
+func BenchmarkCheckLogEntry(b *testing.B) {
+	logKeys := loadTestLogList(b, "Operator A", "Operator B")
+	cert, issuer := newTestCertificateWithValidSCTs(b, logKeys)
+	rawLogEntry := &ctgo.RawLogEntry{Leaf: ctgo.MerkleTreeLeaf{TimestampedEntry: &ctgo.TimestampedEntry{
+		EntryType: ctgo.X509LogEntryType,
+		Timestamp: uint64(time.Now().UnixMilli()),
+		X509Entry: &ctgo.ASN1Cert{Data: cert.Raw},
+	}}, Cert: ctgo.ASN1Cert{Data: cert.Raw}, Chain: []ctgo.ASN1Cert{{Data: issuer.Raw}}}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		CheckLogEntry(rawLogEntry)
+	}
+}
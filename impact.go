@@ -0,0 +1,78 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// LogDistrust describes a hypothetical distrust of one or more logs, such as a log that is about to be retired or rejected, so that the certificates that depend on those logs can be identified.
+type LogDistrust struct {
+	LogIDs   [][sha256.Size]byte // The logs to distrust.
+	At       time.Time           // When the logs are distrusted.  The CT Policies are evaluated at this time, or now if it is in the past.
+	Rejected bool                // Reject the logs, so that none of their SCTs count, instead of retiring them, so that their SCTs issued before At still count.
+}
+
+// CheckLogDistrustImpact re-evaluates each applicable Server Authentication CT Policy (selected using sha256IssuerSPKI, as for CheckComplianceTimeline) as if the logs were distrusted, and returns the names of the CT Policies that the certificate's embedded SCTs would otherwise comply with but would no longer comply with, along with findings that explain the impact.
+func CheckLogDistrustImpact(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, distrust LogDistrust) ([]string, []string) {
+	snapshot := CurrentLogListSnapshot()
+	scts, ctPolicies, findings := applicableServerAuthenticationCTPoliciesForEmbeddedSCTs(cert, sha256IssuerSPKI, snapshot)
+	if findings != nil {
+		return nil, findings
+	}
+
+	at := distrust.At
+	if now := time.Now(); at.Before(now) {
+		at = now
+	}
+	if !cert.NotAfter.After(at) {
+		return nil, []string{fmt.Sprintf("I: Certificate expires before %s, so it is not affected", at.UTC().Format(time.RFC3339))}
+	}
+
+	dependsOnDistrustedLogs := false
+	for _, sct := range scts {
+		if slices.Contains(distrust.LogIDs, sct.LogID.KeyID) {
+			dependsOnDistrustedLogs = true
+		}
+	}
+	if !dependsOnDistrustedLogs {
+		return nil, []string{"I: Certificate contains no SCTs from the distrusted logs"}
+	}
+
+	distrustedState := "Retired"
+	if distrust.Rejected {
+		distrustedState = "Rejected"
+	}
+	newState := func(state *loglist3.LogStates) *loglist3.LogStates {
+		switch {
+		case distrust.Rejected:
+			return &loglist3.LogStates{Rejected: &loglist3.LogState{Timestamp: distrust.At}}
+		case state == nil, state.Pending != nil, state.Rejected != nil:
+			// A log that was never approved cannot be retired.
+			return state
+		case state.Retired != nil && state.Retired.Timestamp.Before(distrust.At):
+			return state
+		default:
+			return &loglist3.LogStates{Retired: &loglist3.LogState{Timestamp: distrust.At}}
+		}
+	}
+
+	var lostPolicies []string
+	for _, ctPolicy := range ctPolicies {
+		distrustedIndex := ctPolicy.logListIndex.withLogStates(distrust.LogIDs, newState)
+		if _, isCompliant := checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctPolicy.logListIndex.findLogs(scts), ctPolicy.logListIndex, ctPolicy.name, at, false); !isCompliant {
+			findings = append(findings, fmt.Sprintf("I: Certificate would not comply with the %s CT Policy at %s, regardless of the distrust", ctPolicy.name, at.UTC().Format(time.RFC3339)))
+		} else if _, isCompliant = checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, distrustedIndex.findLogs(scts), distrustedIndex, ctPolicy.name, at, false); !isCompliant {
+			lostPolicies = append(lostPolicies, ctPolicy.name)
+			findings = append(findings, fmt.Sprintf("W: Certificate would no longer comply with the %s CT Policy if the logs were %s at %s", ctPolicy.name, distrustedState, distrust.At.UTC().Format(time.RFC3339)))
+		} else {
+			findings = append(findings, fmt.Sprintf("I: Certificate would still comply with the %s CT Policy if the logs were %s at %s", ctPolicy.name, distrustedState, distrust.At.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	return lostPolicies, findings
+}
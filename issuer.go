@@ -0,0 +1,208 @@
+package ctlint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/crtsh/ccadb_data"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// IssuerResolver determines the SHA-256 hash of the SubjectPublicKeyInfo of a certificate's issuer, which is the issuer_key_hash needed to verify embedded precertificate SCTs.
+// A nil hash is returned if the issuer cannot be resolved.  The findings explain how the issuer was resolved, or why it could not be.
+type IssuerResolver interface {
+	ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string)
+}
+
+// CCADBIssuerResolver resolves issuers by Authority Key Identifier, using the CCADB data bundled with ctlint.
+type CCADBIssuerResolver struct{}
+
+func (CCADBIssuerResolver) ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string) {
+	if len(cert.AuthorityKeyId) > 0 {
+		if encoded, found := ccadb_data.GetIssuerSPKISHA256ByKeyIdentifier(base64.StdEncoding.EncodeToString(cert.AuthorityKeyId)); found {
+			return &encoded, nil
+		}
+	}
+
+	return nil, []string{"W: Cannot verify SCT signature without issuer SPKI, which could not be found in the available CCADB data"}
+}
+
+// IssuerStore holds a set of issuer certificates, such as private or newly created intermediates that are not yet in the bundled CCADB data.
+// It resolves issuers by matching a certificate's Authority Key Identifier against each issuer's Subject Key Identifier.
+type IssuerStore struct {
+	byKeyIdentifier map[string][]*x509.Certificate
+	bySubject       map[string][]*x509.Certificate
+	fingerprints    map[[sha256.Size]byte]struct{}
+}
+
+func NewIssuerStore() *IssuerStore {
+	return &IssuerStore{
+		byKeyIdentifier: make(map[string][]*x509.Certificate),
+		bySubject:       make(map[string][]*x509.Certificate),
+		fingerprints:    make(map[[sha256.Size]byte]struct{}),
+	}
+}
+
+func (s *IssuerStore) AddIssuer(issuer *x509.Certificate) {
+	fingerprint := sha256.Sum256(issuer.Raw)
+	if _, found := s.fingerprints[fingerprint]; found {
+		return
+	}
+	s.fingerprints[fingerprint] = struct{}{}
+
+	if len(issuer.SubjectKeyId) > 0 {
+		s.byKeyIdentifier[string(issuer.SubjectKeyId)] = append(s.byKeyIdentifier[string(issuer.SubjectKeyId)], issuer)
+	}
+	s.bySubject[string(issuer.RawSubject)] = append(s.bySubject[string(issuer.RawSubject)], issuer)
+}
+
+// LoadFile adds the issuer certificates in a PEM bundle or a DER file to the store.
+func (s *IssuerStore) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Contains(data, []byte("-----BEGIN")) {
+		issuer, err := x509.ParseCertificate(data)
+		if err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		s.AddIssuer(issuer)
+		return nil
+	}
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		issuer, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("%s: %v", filename, err)
+		}
+		s.AddIssuer(issuer)
+	}
+
+	return nil
+}
+
+// LoadDirectory adds the issuer certificates in every file in a directory to the store.
+func (s *IssuerStore) LoadDirectory(dirname string) error {
+	entries, err := os.ReadDir(dirname)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			if err = s.LoadFile(filepath.Join(dirname, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *IssuerStore) ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string) {
+	if len(cert.AuthorityKeyId) == 0 {
+		return nil, []string{"W: Cannot verify SCT signature without issuer SPKI, which cannot be found in the issuer store because the Authority Key Identifier is absent"}
+	}
+
+	return selectIssuer(cert, s.byKeyIdentifier[string(cert.AuthorityKeyId)], "Authority Key Identifier", "issuer store")
+}
+
+// SubjectDNIssuerResolver resolves issuers by matching a certificate's Issuer DN against the Subject DN of each certificate in an IssuerStore.
+// This is useful for certificates that lack an Authority Key Identifier, and for issuers that lack a Subject Key Identifier.
+type SubjectDNIssuerResolver struct {
+	Store *IssuerStore
+}
+
+func (r SubjectDNIssuerResolver) ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string) {
+	return selectIssuer(cert, r.Store.bySubject[string(cert.RawIssuer)], "Issuer DN", "issuer store")
+}
+
+// IssuerResolvers tries each IssuerResolver in turn, until one of them resolves the issuer.
+type IssuerResolvers []IssuerResolver
+
+func (resolvers IssuerResolvers) ResolveIssuerSPKISHA256(cert *x509.Certificate) (*[sha256.Size]byte, []string) {
+	var failureFindings []string
+	for _, resolver := range resolvers {
+		sha256IssuerSPKI, findings := resolver.ResolveIssuerSPKISHA256(cert)
+		if sha256IssuerSPKI != nil {
+			return sha256IssuerSPKI, findings
+		}
+		failureFindings = append(failureFindings, findings...)
+	}
+
+	return nil, failureFindings
+}
+
+// CheckIssuer checks that issuer actually issued cert, by comparing the Issuer and Subject DNs and the Authority and Subject Key Identifiers, and by verifying cert's signature using issuer's public key.
+func CheckIssuer(cert *x509.Certificate, issuer *x509.Certificate) []string {
+	findings, _ := checkIssuer(cert, issuer)
+	return findings
+}
+
+func checkIssuer(cert *x509.Certificate, issuer *x509.Certificate) ([]string, bool) {
+	if cert == nil {
+		return []string{"E: Certificate not provided"}, false
+	} else if issuer == nil {
+		return []string{"E: Issuer certificate not provided"}, false
+	}
+
+	var findings []string
+	if !bytes.Equal(cert.RawIssuer, issuer.RawSubject) {
+		if cert.Issuer.String() == issuer.Subject.String() {
+			findings = append(findings, "N: Issuer DN is not byte-for-byte identical to the issuer certificate's Subject DN")
+		} else {
+			findings = append(findings, "E: Issuer DN does not match the issuer certificate's Subject DN")
+		}
+	}
+
+	if len(cert.AuthorityKeyId) > 0 && len(issuer.SubjectKeyId) > 0 && !bytes.Equal(cert.AuthorityKeyId, issuer.SubjectKeyId) {
+		findings = append(findings, "E: Authority Key Identifier does not match the issuer certificate's Subject Key Identifier")
+	}
+
+	isIssuer := true
+	if err := issuer.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		findings = append(findings, "E: Signature cannot be verified using the issuer certificate's public key")
+		isIssuer = false
+	}
+
+	return findings, isIssuer
+}
+
+func selectIssuer(cert *x509.Certificate, candidates []*x509.Certificate, matchedBy string, source string) (*[sha256.Size]byte, []string) {
+	var spkiHashes [][sha256.Size]byte
+	for _, candidate := range candidates {
+		spkiHash := sha256.Sum256(candidate.RawSubjectPublicKeyInfo)
+		if !slices.Contains(spkiHashes, spkiHash) {
+			spkiHashes = append(spkiHashes, spkiHash)
+		}
+	}
+
+	switch {
+	case len(spkiHashes) == 0:
+		return nil, []string{fmt.Sprintf("W: Cannot verify SCT signature without issuer SPKI, which could not be found in the %s by %s", source, matchedBy)}
+	case len(spkiHashes) == 1:
+		// All of the candidates (e.g., cross-certificates) share the same key.
+		return &spkiHashes[0], nil
+	}
+
+	// Several different keys match (e.g., colliding key identifiers, or re-used DNs), so pick the one that verifies the certificate's signature.
+	for _, candidate := range candidates {
+		if candidate.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil {
+			spkiHash := sha256.Sum256(candidate.RawSubjectPublicKeyInfo)
+			return &spkiHash, []string{fmt.Sprintf("N: Multiple issuers with different keys match the %s; selected the one that verifies the certificate signature", matchedBy)}
+		}
+	}
+
+	return nil, []string{fmt.Sprintf("W: Cannot verify SCT signature without issuer SPKI, because none of the issuers in the %s that match the %s verify the certificate signature", source, matchedBy)}
+}
@@ -0,0 +1,294 @@
+package ctlint
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LintScope identifies the kinds of object for which a lint's findings are reported.
+type LintScope int
+
+const (
+	CertificateScope    LintScope = 1 << iota // Reported by CheckCertificate and its variants.
+	PrecertificateScope                       // Reported by CheckPrecertificate and its variants.
+	LogEntryScope                             // Reported by the log entry checks, such as CheckLogEntry.
+)
+
+// The sources of the requirements that ctlint checks.  Where possible, these are the same as zlint's lint sources.
+const (
+	SourceRFC5280                     = "RFC5280"
+	SourceRFC6962                     = "RFC6962"
+	SourceRFC9162                     = "RFC9162"
+	SourceStaticCTAPI                 = "static-ct-api"
+	SourceCABFBaselineRequirements    = "CABF_BR"
+	SourceCTPolicies                  = "CT_Policies"
+	SourceChromeCTPolicy              = "Chrome"
+	SourceMarkCertificateGuidelines   = "BIMI"
+	SourceCommunity                   = "Community"
+	ctPoliciesCitation                = "https://googlechrome.github.io/CertificateTransparency/ct_policy.html; https://support.apple.com/en-us/103214; https://wiki.mozilla.org/SecurityEngineering/Certificate_Transparency"
+	markCertificateGuidelinesCitation = "Mark Certificate Guidelines section 3.3.1; https://bimigroup.org/resources/VMC_Requirements_latest.pdf"
+)
+
+var (
+	rfc5280Date = time.Date(2008, time.May, 1, 0, 0, 0, 0, time.UTC)
+	rfc6962Date = time.Date(2013, time.June, 1, 0, 0, 0, 0, time.UTC)
+	rfc9162Date = time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC)
+	// Chrome began to enforce its CT Policy for certificates issued after April 30, 2018, which was the first CT Policy to be enforced for all certificates.
+	ctPoliciesDate                  = time.Date(2018, time.April, 30, 0, 0, 0, 0, time.UTC)
+	precertSigningCADeprecationDate = time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+	lintFormatVerbPattern           = regexp.MustCompile(`%[a-z]`)
+	lintCodesByMessage              = make(map[string][]*Lint)
+	lintsWithVariableMessages       []*Lint
+	lintMessagePatterns             = make(map[*Lint][]*regexp.Regexp)
+)
+
+// Lint describes one of ctlint's checks.  Every finding that ctlint reports belongs to exactly one Lint, whose Code is stable, so that findings can be counted and filtered without depending on their wording or on the variable text (such as log descriptions and issuer names) that they include.
+type Lint struct {
+	Code          string    // Stable identifier, such as "sct_invalid_signature".
+	Severity      string    // "F", "E", "W", "N", or "I".  CT Policy findings are reported as "I" instead of "E" or "W" when the CT Policy might not apply.
+	Scope         LintScope // The kinds of object for which the lint's findings are reported, if any of CertificateScope, PrecertificateScope and LogEntryScope.
+	Source        string
+	Citation      string
+	EffectiveDate time.Time // The zero time if the requirement has always applied.
+	Description   string
+	messages      []string // The format strings of the lint's findings, without their severities.
+}
+
+// Lints lists all of ctlint's checks.
+var Lints = []*Lint{
+	// Certificates.
+	{Code: "certificate_not_provided", Severity: "E", Scope: CertificateScope, Source: SourceCommunity, Description: "A certificate must be provided", messages: []string{"Certificate not provided"}},
+	{Code: "supplied_issuer_did_not_issue_certificate", Severity: "W", Scope: CertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 6.1", EffectiveDate: rfc5280Date, Description: "The supplied issuer certificate must have issued the certificate", messages: []string{"Supplied issuer certificate did not issue this certificate, so it will not be used to verify SCT signatures"}},
+	{Code: "multiple_sct_list_extensions", Severity: "E", Scope: CertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.2", EffectiveDate: rfc5280Date, Description: "A certificate must not include more than one SCT list extension", messages: []string{"Multiple SCT list extensions are present"}},
+	{Code: "multiple_transparency_information_extensions", Severity: "E", Scope: CertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.2", EffectiveDate: rfc5280Date, Description: "A certificate must not include more than one transparency information extension", messages: []string{"Multiple transparency information extensions are present"}},
+	{Code: "certificate_has_poison_extension", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "Only a precertificate may include the precertificate poison extension", messages: []string{"Precertificate 'poison' extension is present"}},
+	{Code: "ocsp_sct_list_extension_present", Severity: "E", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "The OCSP SCT list extension belongs in OCSP responses, not in certificates", messages: []string{"OCSP SCT list extension is present"}},
+	{Code: "policy_group_identified", Severity: "I", Scope: CertificateScope, Description: "The CT Policy group of the certificate was identified", messages: []string{"Identified as a %s, because the %s"}},
+	{Code: "sct_list_absent", Severity: "N", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Server Authentication certificates should include embedded SCTs", messages: []string{"SCT list extension is absent in this %s"}},
+	{Code: "mark_certificate_sct_list_absent", Severity: "E", Scope: CertificateScope, Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "Mark Certificates must include embedded SCTs", messages: []string{"SCT list extension is absent in this %s"}},
+	{Code: "no_ct_policies_apply", Severity: "I", Scope: CertificateScope, Description: "No CT Policies apply to the certificate", messages: []string{"No CT policies apply to this %s", "SCT list has no applicable CT Policies"}},
+	{Code: "sct_list_identified", Severity: "I", Scope: CertificateScope, Description: "The certificate includes embedded SCTs", messages: []string{"%s with embedded SCT list identified"}},
+	{Code: "v1_and_v2_scts", Severity: "I", Scope: CertificateScope, Description: "The certificate includes both RFC6962 and RFC9162 SCTs", messages: []string{"Certificate contains both RFC6962 (v1) and RFC9162 (v2) SCTs"}},
+	{Code: "only_v2_scts", Severity: "N", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "No CT Policy recognizes RFC9162 SCTs, so certificates should also include RFC6962 SCTs", messages: []string{"Certificate contains only RFC9162 (v2) SCTs, which are not recognized by any CT Policy"}},
+	{Code: "tbs_certificate_unparseable", Severity: "E", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.1", EffectiveDate: rfc5280Date, Description: "The TBSCertificate must be correctly encoded", messages: []string{"TBSCertificate could not be parsed"}},
+
+	// Precertificates.
+	{Code: "precertificate_not_provided", Severity: "E", Scope: PrecertificateScope, Source: SourceCommunity, Description: "A precertificate must be provided", messages: []string{"Precertificate not provided"}},
+	{Code: "precertificate_identified", Severity: "I", Scope: PrecertificateScope, Description: "The certificate is a precertificate", messages: []string{"Precertificate identified"}},
+	{Code: "supplied_issuer_did_not_sign_precertificate", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "The supplied issuer certificate must have signed the precertificate", messages: []string{"Supplied issuer certificate did not sign this precertificate, so the issuer_key_hash cannot be determined"}},
+	{Code: "precertificate_signed_by_ca", Severity: "I", Scope: PrecertificateScope, Description: "The precertificate is signed by the CA that will issue the final certificate", messages: []string{"Precertificate is signed directly by the CA"}},
+	{Code: "precertificate_signed_by_precert_signing_certificate", Severity: "I", Scope: PrecertificateScope, Description: "The precertificate is signed by a Precertificate Signing Certificate", messages: []string{"Precertificate is signed by a Precertificate Signing Certificate"}},
+	{Code: "issuer_key_hash_undetermined", Severity: "W", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The issuer_key_hash is derived from the key of the CA that issued the Precertificate Signing Certificate", messages: []string{"Cannot determine the issuer_key_hash without the CA certificate that issued the Precertificate Signing Certificate, whose SPKI could not be found in the available CCADB data"}},
+	{Code: "issuer_key_hash", Severity: "I", Scope: PrecertificateScope, Description: "The issuer_key_hash that logs should use for the precertificate's entries", messages: []string{"issuer_key_hash for this precertificate's log entries is %s"}},
+	{Code: "multiple_poison_extensions", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.2", EffectiveDate: rfc5280Date, Description: "A precertificate must not include more than one poison extension", messages: []string{"Multiple Precertificate 'poison' extensions are present"}},
+	{Code: "poison_extension_not_critical", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "The precertificate poison extension must be critical", messages: []string{"Precertificate 'poison' extension is not critical"}},
+	{Code: "poison_extension_incorrect_contents", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "The precertificate poison extension must contain an ASN.1 NULL", messages: []string{"Precertificate 'poison' extension has incorrect contents"}},
+	{Code: "poison_extension_absent", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A precertificate must include the precertificate poison extension", messages: []string{"Precertificate 'poison' extension is absent"}},
+	{Code: "precertificate_has_sct_list_extension", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "A precertificate must not include embedded SCTs", messages: []string{"SCT list extension is present"}},
+	{Code: "precertificate_has_transparency_information_extension", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 7.1.2", EffectiveDate: rfc9162Date, Description: "A precertificate must not include the transparency information extension", messages: []string{"Transparency information extension is present"}},
+	{Code: "precert_signing_ca_identified", Severity: "I", Scope: PrecertificateScope, Description: "The precertificate was issued by a Precertificate Signing CA", messages: []string{"Precertificate issued by %s"}},
+	{Code: "precert_signing_ca_deprecated", Severity: "E", Scope: PrecertificateScope, Source: SourceChromeCTPolicy, Citation: "https://googlechrome.github.io/CertificateTransparency/ct_policy.html", EffectiveDate: precertSigningCADeprecationDate, Description: "Precertificates must not be issued by Precertificate Signing CAs after March 15, 2026", messages: []string{"Precertificate issued by %s after March 15, 2026"}},
+
+	// Mark Certificates.
+	{Code: "mark_precertificate_subject_attribute_absent", Severity: "E", Scope: PrecertificateScope, Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate precertificate must include all of the Subject data", messages: []string{"Mark Certificate precertificate Subject does not include the %s attribute"}},
+	{Code: "multiple_logotype_extensions", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.2", EffectiveDate: rfc5280Date, Description: "A certificate must not include more than one logotype extension", messages: []string{"Multiple logotype extensions are present"}},
+	{Code: "logotype_extension_unparseable", Severity: "E", Scope: PrecertificateScope, Source: SourceMarkCertificateGuidelines, Citation: "RFC3709 section 4.1", Description: "The logotype extension must be correctly encoded", messages: []string{"Logotype extension could not be parsed"}},
+	{Code: "logotype_extension_trailing_data", Severity: "E", Scope: PrecertificateScope, Source: SourceMarkCertificateGuidelines, Citation: "RFC3709 section 4.1", Description: "The logotype extension must not contain trailing data", messages: []string{"Logotype extension contains trailing data"}},
+	{Code: "mark_precertificate_mark_representation_absent", Severity: "E", Scope: PrecertificateScope, Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate precertificate must include the Mark Representation", messages: []string{"Mark Certificate precertificate does not include the Mark Representation (logotype extension is absent)"}},
+	{Code: "mark_certificate_subject_mismatch", Severity: "E", Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate's Subject must match its precertificate's Subject", messages: []string{"Mark Certificate Subject does not exactly match the precertificate Subject"}},
+	{Code: "mark_certificate_logotype_absent", Severity: "E", Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate must include the Mark Representation", messages: []string{"Mark Certificate logotype extension is absent"}},
+	{Code: "mark_precertificate_logotype_absent", Severity: "E", Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate's precertificate must include the Mark Representation", messages: []string{"Mark Certificate precertificate logotype extension is absent"}},
+	{Code: "mark_certificate_logotype_mismatch", Severity: "E", Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate's Mark Representation must match its precertificate's Mark Representation", messages: []string{"Mark Certificate logotype extension does not exactly match the precertificate logotype extension"}},
+	{Code: "mark_certificate_no_approved_sct", Severity: "E", Scope: CertificateScope, Source: SourceMarkCertificateGuidelines, Citation: markCertificateGuidelinesCitation, Description: "A Mark Certificate must include an SCT from a log that is approved by the Mark Certificate Guidelines", messages: []string{"SCT list contains no SCTs from logs currently approved by the Mark Certificate Guidelines"}},
+
+	// Issuers.
+	{Code: "issuer_certificate_not_provided", Severity: "E", Source: SourceCommunity, Description: "An issuer certificate must be provided", messages: []string{"Issuer certificate not provided"}},
+	{Code: "issuer_dn_not_identical", Severity: "N", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 7.1", EffectiveDate: rfc5280Date, Description: "The Issuer DN should be byte-for-byte identical to the issuer certificate's Subject DN", messages: []string{"Issuer DN is not byte-for-byte identical to the issuer certificate's Subject DN"}},
+	{Code: "issuer_dn_mismatch", Severity: "E", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 6.1.3", EffectiveDate: rfc5280Date, Description: "The Issuer DN must match the issuer certificate's Subject DN", messages: []string{"Issuer DN does not match the issuer certificate's Subject DN"}},
+	{Code: "authority_key_identifier_mismatch", Severity: "E", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 4.2.1.1", EffectiveDate: rfc5280Date, Description: "The Authority Key Identifier must match the issuer certificate's Subject Key Identifier", messages: []string{"Authority Key Identifier does not match the issuer certificate's Subject Key Identifier"}},
+	{Code: "signature_not_verified_by_issuer", Severity: "E", Scope: CertificateScope | PrecertificateScope, Source: SourceRFC5280, Citation: "RFC5280 section 6.1.3", EffectiveDate: rfc5280Date, Description: "The signature must be verifiable using the issuer certificate's public key", messages: []string{"Signature cannot be verified using the issuer certificate's public key"}},
+	{Code: "issuer_spki_not_in_ccadb", Severity: "W", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The issuer's key is needed to verify SCT signatures", messages: []string{"Cannot verify SCT signature without issuer SPKI, which could not be found in the available CCADB data"}},
+	{Code: "issuer_spki_not_in_issuer_store", Severity: "W", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The issuer's key is needed to verify SCT signatures", messages: []string{"Cannot verify SCT signature without issuer SPKI, which cannot be found in the issuer store because the Authority Key Identifier is absent", "Cannot verify SCT signature without issuer SPKI, which could not be found in the %s by %s", "Cannot verify SCT signature without issuer SPKI, because none of the issuers in the %s that match the %s verify the certificate signature"}},
+	{Code: "multiple_issuers_match", Severity: "N", Scope: CertificateScope, Description: "More than one issuer certificate matched the certificate", messages: []string{"Multiple issuers with different keys match the %s; selected the one that verifies the certificate signature"}},
+
+	// SCTs.
+	{Code: "sct_version_not_v1", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "SCTs must be version 1", messages: []string{"SCT version is not V1"}},
+	{Code: "sct_timestamp_in_future", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "SCT timestamps must not be in the future", messages: []string{"SCT timestamp is in the future"}},
+	{Code: "sct_unknown_log", Severity: "N", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "SCTs should be from known logs", messages: []string{"SCT is from an unknown log"}},
+	{Code: "sct_invalid_signature", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "SCT signatures must be valid", messages: []string{"SCT has an invalid signature purporting to be from %s", "SCT has an invalid signature"}},
+	{Code: "sct_valid_signature", Severity: "I", Scope: CertificateScope, Description: "The SCT's signature is valid", messages: []string{"SCT has a valid signature from %s", "SCT has a valid signature"}},
+	{Code: "cannot_remove_poison_extension", Severity: "E", Scope: PrecertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The TBSCertificate that SCTs cover is derived by removing the precertificate poison extension", messages: []string{"Cannot remove Precertificate 'poison' extension to derive TBSCertificate"}},
+	{Code: "cannot_remove_sct_list_extension", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The TBSCertificate that SCTs cover is derived by removing the SCT list extension", messages: []string{"Cannot remove SCT List extension to derive TBSCertificate"}},
+	{Code: "sct_list_extension_unparseable", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "The SCT list extension must contain an OCTET STRING", messages: []string{"SCT list extension could not be parsed"}},
+	{Code: "sct_list_extension_trailing_data", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "The SCT list extension must not contain trailing data", messages: []string{"SCT list extension contains trailing data"}},
+	{Code: "sct_list_unparseable", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "The SCT list must be correctly encoded", messages: []string{"SCT list could not be parsed", "SCTs could not be parsed from SCT list"}},
+	{Code: "sct_list_trailing_data", Severity: "E", Scope: CertificateScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "The SCT list must not contain trailing data", messages: []string{"SCT list contains trailing data"}},
+	{Code: "log_operator_disagreement", Severity: "N", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "The log lists should agree on the operator of each log", messages: []string{"Log lists disagree on the operator of %s: %s"}},
+	{Code: "sct_from_test_log", Severity: "E", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Production certificates must not include SCTs from test logs", messages: []string{"SCT from %s, which is a test log, is embedded in a production certificate"}},
+	{Code: "sct_from_mimic_log", Severity: "E", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Production certificates must not include SCTs from logs that mimic production logs", messages: []string{"SCT from %s, which mimics a production log, is embedded in a production certificate"}},
+	{Code: "certificate_expires_outside_temporal_interval", Severity: "E", Scope: CertificateScope, Source: SourceCTPolicies, Citation: "https://googlechrome.github.io/CertificateTransparency/log_policy.html", EffectiveDate: ctPoliciesDate, Description: "Certificates must expire within the temporal interval of each log that supplied their embedded SCTs", messages: []string{"Certificate expires outside log's temporal interval"}},
+	{Code: "not_before_older_than_sct", Severity: "E", Scope: CertificateScope, Source: SourceCABFBaselineRequirements, Citation: "CA/Browser Forum Baseline Requirements section 7.1.2.7 (Ballot SC-062)", EffectiveDate: SC62EffectiveDate, Description: "The notBefore date must not be more than 48 hours before the certificate was signed", messages: []string{"Certificate notBefore timestamp >48 hours older than at least one embedded SCT"}},
+	{Code: "sct_before_log_pending", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "SCTs should not be timestamped before the log that issued them became Pending", messages: []string{"SCT from %s has a timestamp before the log became Pending in the %s, so the log's key may not yet have existed"}},
+	{Code: "sct_after_log_read_only", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "ReadOnly logs should not issue SCTs", messages: []string{"SCT from %s has a timestamp after the log became ReadOnly in the %s"}},
+	{Code: "sct_after_log_retired", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Retired logs should not issue SCTs", messages: []string{"SCT from %s has a timestamp after the log was Retired in the %s"}},
+	{Code: "sct_after_log_rejected", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Rejected logs should not issue SCTs", messages: []string{"SCT from %s has a timestamp after the log was Rejected in the %s"}},
+
+	// RFC9162 SCTs.
+	{Code: "transparency_information_unparseable", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 7.1.2", EffectiveDate: rfc9162Date, Description: "The transparency information extension must be correctly encoded", messages: []string{"Transparency information extension could not be parsed", "Transparency information TransItemList could not be parsed"}},
+	{Code: "transparency_information_trailing_data", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 7.1.2", EffectiveDate: rfc9162Date, Description: "The transparency information extension must not contain trailing data", messages: []string{"Transparency information extension contains trailing data"}},
+	{Code: "cannot_remove_transparency_information_extension", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 4.6", EffectiveDate: rfc9162Date, Description: "The TBSCertificate that RFC9162 SCTs cover is derived by removing the transparency information extension", messages: []string{"Cannot remove transparency information extension to derive TBSCertificate"}},
+	{Code: "x509_sct_v2_embedded", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 7.1.2", EffectiveDate: rfc9162Date, Description: "An x509_sct_v2 SCT cannot be embedded in the certificate that it covers", messages: []string{"Transparency information extension contains an x509_sct_v2 SCT, which cannot be embedded in the certificate it covers"}},
+	{Code: "trans_item_unchecked", Severity: "I", Scope: CertificateScope, Description: "The transparency information extension contains a TransItem that ctlint does not check", messages: []string{"Transparency information extension contains an unchecked TransItem (type %d)"}},
+	{Code: "trans_item_not_permitted", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 7.1.2", EffectiveDate: rfc9162Date, Description: "The transparency information extension may only contain TransItems of the permitted types", messages: []string{"Transparency information extension contains a TransItem of a type (%d) that is not permitted in certificates"}},
+	{Code: "v2_sct_timestamp_in_future", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 4.8", EffectiveDate: rfc9162Date, Description: "RFC9162 SCT timestamps must not be in the future", messages: []string{"RFC9162 SCT timestamp is in the future"}},
+	{Code: "v2_sct_unknown_log", Severity: "N", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 4.8", EffectiveDate: rfc9162Date, Description: "RFC9162 SCTs should be from known logs", messages: []string{"RFC9162 SCT is from an unknown log (%s)"}},
+	{Code: "v2_sct_signature_input_unconstructible", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 4.8", EffectiveDate: rfc9162Date, Description: "The data that an RFC9162 SCT signs must be constructible", messages: []string{"RFC9162 SCT signature input could not be constructed"}},
+	{Code: "v2_sct_valid_signature", Severity: "I", Scope: CertificateScope, Description: "The RFC9162 SCT's signature is valid", messages: []string{"RFC9162 SCT has a valid signature from %s (%s)"}},
+	{Code: "v2_sct_invalid_signature", Severity: "E", Scope: CertificateScope, Source: SourceRFC9162, Citation: "RFC9162 section 4.8", EffectiveDate: rfc9162Date, Description: "RFC9162 SCT signatures must be valid", messages: []string{"RFC9162 SCT has an invalid signature purporting to be from %s (%s)"}},
+
+	// CT Policies.
+	{Code: "log_list_stale", Severity: "F", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "CT Policies are only enforced using log lists that are less than 70 days old", messages: []string{"The available %s log list is older than 70 days: Update ctlint, or run \"ctlint update-loglists\"!"}},
+	{Code: "log_list_snapshot_version", Severity: "I", Scope: CertificateScope, Description: "The version of the log list snapshot that the SCTs were checked against", messages: []string{"SCTs checked against log list snapshot version %d"}},
+	{Code: "ct_policy_log_list_source", Severity: "I", Scope: CertificateScope, Description: "The log list that a CT Policy was evaluated against", messages: []string{"%s CT Policy evaluated using the log list from %s"}},
+	{Code: "expired_certificate_not_checked", Severity: "N", Scope: CertificateScope, Description: "CT Policies are not evaluated for expired certificates", messages: []string{"SCT list in expired certificate not checked for CT Policy compliance"}},
+	{Code: "issuing_ca_not_in_ccadb", Severity: "N", Scope: CertificateScope, Description: "CT Policies only apply to publicly-trusted hierarchies", messages: []string{"Issuing CA is not in the available CCADB data, so CT Policy findings are informational only"}},
+	{Code: "ct_policy_not_applicable", Severity: "I", Scope: CertificateScope, Description: "A CT Policy does not apply, because its root program does not trust the issuing hierarchy", messages: []string{"%s CT Policy does not apply, because the issuing hierarchy is not trusted by %s"}},
+	{Code: "sct_log_not_counted", Severity: "N", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Only SCTs from approved logs count towards CT Policy compliance", messages: []string{"SCT from log %s does not count towards the %s CT Policy, because the log is not in the %s log list", "SCT from %s does not count towards the %s CT Policy, because the log has no state in the %s log list", "SCT from %s does not count towards the %s CT Policy, because the log is %s in the %s log list"}},
+	{Code: "sct_list_no_currently_approved_log", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "At least one embedded SCT must be from a currently approved log", messages: []string{"SCT list contains no SCTs from logs currently approved by the %s CT Policy"}},
+	{Code: "sct_list_insufficient_scts", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Certificates must include enough SCTs from approved logs for their lifetime", messages: []string{"SCT list contains fewer approved SCTs than required by the %s CT Policy"}},
+	{Code: "sct_list_relies_on_qualified_log", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Certificates should not rely on SCTs from logs that are not yet broadly usable", messages: []string{"SCT list satisfies the %s CT Policy using at least 1 SCT from an Admissible log that is not yet broadly usable", "SCT list satisfies the %s CT Policy using at least 1 SCT from a Qualified log that is not yet Usable"}},
+	{Code: "sct_list_insufficient_operators", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "Embedded SCTs must be from at least two distinct log operators", messages: []string{"SCT list contains SCTs from fewer log operators than required by the %s CT Policy"}},
+	{Code: "sct_list_no_rfc6962_log", Severity: "W", Scope: CertificateScope, Source: SourceCTPolicies, Citation: ctPoliciesCitation, EffectiveDate: ctPoliciesDate, Description: "At least one embedded SCT must be from an RFC6962-compliant log", messages: []string{"SCT list contains fewer SCTs from RFC6962-compliant logs than required by the %s CT Policy"}},
+
+	// Precertificate Signing Certificates.
+	{Code: "precert_signing_certificate_identified", Severity: "I", Scope: CertificateScope, Description: "The certificate is a Precertificate Signing Certificate", messages: []string{"Precertificate Signing Certificate identified"}},
+	{Code: "precert_signing_certificate_not_provided", Severity: "E", Source: SourceCommunity, Description: "A Precertificate Signing Certificate must be provided", messages: []string{"Precertificate Signing Certificate not provided"}},
+	{Code: "precert_signing_certificate_issuer_not_provided", Severity: "E", Source: SourceCommunity, Description: "The issuer of the Precertificate Signing Certificate must be provided", messages: []string{"Issuer of Precertificate Signing Certificate not provided"}},
+	{Code: "precert_signing_certificate_ct_eku_absent", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate must include the Certificate Transparency EKU", messages: []string{"Precertificate Signing Certificate does not contain the Certificate Transparency EKU"}},
+	{Code: "precert_signing_certificate_other_ekus", Severity: "N", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate should only include the Certificate Transparency EKU", messages: []string{"Precertificate Signing Certificate contains EKUs other than the Certificate Transparency EKU"}},
+	{Code: "precert_signing_certificate_not_ca", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate must assert basicConstraints cA=TRUE", messages: []string{"Precertificate Signing Certificate does not assert basicConstraints cA=TRUE"}},
+	{Code: "precert_signing_certificate_path_length", Severity: "N", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate should limit pathLenConstraint to 0", messages: []string{"Precertificate Signing Certificate does not limit basicConstraints pathLenConstraint to 0"}},
+	{Code: "precert_signing_certificate_key_cert_sign_absent", Severity: "E", Source: SourceRFC5280, Citation: "RFC5280 section 4.2.1.3", EffectiveDate: rfc5280Date, Description: "A Precertificate Signing Certificate must assert the keyCertSign key usage", messages: []string{"Precertificate Signing Certificate does not assert the keyCertSign key usage"}},
+	{Code: "precert_signing_certificate_issued_by_precert_signing_certificate", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate must be issued by the CA that will issue the final certificate", messages: []string{"Precertificate Signing Certificate is issued by another Precertificate Signing Certificate"}},
+	{Code: "precert_signing_certificate_not_issued_by_ca", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "A Precertificate Signing Certificate must be directly certified by the CA that will issue the final certificate", messages: []string{"Precertificate Signing Certificate is not directly issued by the specified CA certificate"}},
+	{Code: "final_certificate_issuer_mismatch", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "The final certificate must be issued by the CA that issued the Precertificate Signing Certificate", messages: []string{"Final certificate is not issued by the CA that issued the Precertificate Signing Certificate"}},
+	{Code: "sct_issuer_key_hash_from_precert_signing_certificate", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "The issuer_key_hash must be derived from the key of the CA, not of the Precertificate Signing Certificate", messages: []string{"SCT issuer_key_hash was derived from the Precertificate Signing Certificate's key instead of the CA's key"}},
+	{Code: "sct_issuer_key_hash_invalid", Severity: "E", Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "SCT signatures must be verifiable using the issuer_key_hash of the CA", messages: []string{"SCT signature cannot be verified using an issuer_key_hash derived from either the CA's key or the Precertificate Signing Certificate's key"}},
+
+	// RFC9162 precertificates.
+	{Code: "v2_precertificate_not_provided", Severity: "E", Source: SourceCommunity, Description: "An RFC9162 precertificate must be provided", messages: []string{"RFC9162 precertificate not provided"}},
+	{Code: "v2_precertificate_identified", Severity: "I", Description: "The object is an RFC9162 precertificate", messages: []string{"RFC9162 precertificate identified"}},
+	{Code: "v2_precertificate_unparseable", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate must be a correctly encoded CMS object", messages: []string{"RFC9162 precertificate could not be parsed: %v", "RFC9162 precertificate is not a CMS SignedData object", "RFC9162 precertificate TBSCertificate could not be parsed"}},
+	{Code: "v2_precertificate_econtent_type", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's eContentType must be 1.3.101.78", messages: []string{"RFC9162 precertificate eContentType is not 1.3.101.78"}},
+	{Code: "v2_precertificate_signed_data_version", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignedData version must be v3", messages: []string{"RFC9162 precertificate SignedData.version is not v3"}},
+	{Code: "v2_precertificate_certificates_present", Severity: "N", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignedData should omit certificates", messages: []string{"RFC9162 precertificate SignedData.certificates is present"}},
+	{Code: "v2_precertificate_crls_present", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignedData must omit crls", messages: []string{"RFC9162 precertificate SignedData.crls is present"}},
+	{Code: "v2_precertificate_signer_info_count", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate must contain exactly one SignerInfo", messages: []string{"RFC9162 precertificate does not contain exactly one SignerInfo"}},
+	{Code: "v2_precertificate_signer_info_version", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignerInfo version must be v3", messages: []string{"RFC9162 precertificate SignerInfo.version is not v3"}},
+	{Code: "v2_precertificate_sid", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignerInfo sid must be the issuer's subjectKeyIdentifier", messages: []string{"RFC9162 precertificate SignerInfo.sid does not use the subjectKeyIdentifier option", "RFC9162 precertificate SignerInfo.sid does not match the issuer certificate's Subject Key Identifier"}},
+	{Code: "v2_precertificate_digest_algorithms", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's digestAlgorithms must be the same as its SignerInfo's digestAlgorithm", messages: []string{"RFC9162 precertificate SignedData.digestAlgorithms is not the same as SignerInfo.digestAlgorithm"}},
+	{Code: "v2_precertificate_signed_attrs_present", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignerInfo must omit signedAttrs", messages: []string{"RFC9162 precertificate SignerInfo.signedAttrs is present"}},
+	{Code: "v2_precertificate_unsigned_attrs_present", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's SignerInfo must omit unsignedAttrs", messages: []string{"RFC9162 precertificate SignerInfo.unsignedAttrs is present"}},
+	{Code: "v2_precertificate_signature_algorithm", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's signatureAlgorithm must be the same as its TBSCertificate's signature", messages: []string{"RFC9162 precertificate SignerInfo.signatureAlgorithm differs from TBSCertificate.signature"}},
+	{Code: "v2_precertificate_invalid_signature", Severity: "E", Source: SourceRFC9162, Citation: "RFC9162 section 3.2", EffectiveDate: rfc9162Date, Description: "An RFC9162 precertificate's signature must be verifiable using the issuer certificate's public key", messages: []string{"RFC9162 precertificate signature cannot be verified using the issuer certificate's public key"}},
+
+	// Log entries.
+	{Code: "log_entry_undecodable", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 4.6", EffectiveDate: rfc6962Date, Description: "Log entries must be correctly encoded", messages: []string{"Log entry could not be decoded: %v", "Log entry chain certificate #%d could not be parsed", "Log entry does not contain a certificate", "Log entry certificate could not be parsed", "Log entry does not contain a precertificate", "Log entry precertificate could not be parsed"}},
+	{Code: "log_entry_x509_entry_contains_precertificate", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.1", EffectiveDate: rfc6962Date, Description: "Precertificates must be logged as precert_entry entries", messages: []string{"Log entry of type x509_entry contains a precertificate"}},
+	{Code: "log_entry_unsupported_type", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.4", EffectiveDate: rfc6962Date, Description: "Log entries must be x509_entry or precert_entry entries", messages: []string{"Log entry has unsupported entry type %v"}},
+	{Code: "log_entry_chain_incomplete", Severity: "W", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 4.6", EffectiveDate: rfc6962Date, Description: "A precert_entry's chain should include the CA that issued the Precertificate Signing Certificate", messages: []string{"Log entry chain does not contain the CA certificate that issued the Precertificate Signing Certificate, so the issuer_key_hash cannot be checked"}},
+	{Code: "log_entry_issuer_key_hash_mismatch", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "A precert_entry's issuer_key_hash must be the hash of the key of the CA that will issue the final certificate", messages: []string{"Log entry issuer_key_hash does not match the key of the CA that will issue the final certificate"}},
+	{Code: "log_entry_tbs_certificate_mismatch", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.2", EffectiveDate: rfc6962Date, Description: "A precert_entry's TBSCertificate must be derived from the submitted precertificate", messages: []string{"Expected log entry TBSCertificate could not be constructed: %v", "Log entry TBSCertificate does not match the submitted precertificate"}},
+	{Code: "log_entry_sct_for_x509_entry", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "SCTs embedded in a certificate must be issued for its precertificate", messages: []string{"Certificate embeds an SCT with the timestamp of its own x509_entry, but embedded SCTs must be issued for a precert_entry"}},
+	{Code: "log_entry_sct_extensions_mismatch", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.4", EffectiveDate: rfc6962Date, Description: "An SCT's extensions must be those of the log entry that it was issued for", messages: []string{"Embedded SCT has the timestamp of the log's precert_entry for this certificate (at index %d), but different extensions"}},
+	{Code: "log_entry_certificate_before_precertificate", Severity: "E", Scope: LogEntryScope, Source: SourceRFC6962, Citation: "RFC6962 section 3.3", EffectiveDate: rfc6962Date, Description: "A certificate cannot be logged before the precertificate whose SCTs it embeds", messages: []string{"Log entry timestamp is before the timestamp of the log's precert_entry for this certificate (at index %d)"}},
+	{Code: "log_entry_sct_not_embedded", Severity: "N", Scope: LogEntryScope, Description: "The certificate does not embed the SCT that the log issued for its precertificate", messages: []string{"Certificate does not embed the SCT that the log issued for its precert_entry (at index %d)"}},
+	{Code: "log_entry_extensions_unparseable", Severity: "E", Scope: LogEntryScope, Source: SourceStaticCTAPI, Citation: "https://c2sp.org/static-ct-api", Description: "A static-ct-api log entry's extensions must be correctly encoded", messages: []string{"Log entry extensions could not be parsed: %v"}},
+	{Code: "log_entry_leaf_index_absent", Severity: "E", Scope: LogEntryScope, Source: SourceStaticCTAPI, Citation: "https://c2sp.org/static-ct-api", Description: "A static-ct-api log entry must include the leaf_index extension", messages: []string{"Log entry leaf_index extension is absent"}},
+	{Code: "log_entry_leaf_index_mismatch", Severity: "E", Scope: LogEntryScope, Source: SourceStaticCTAPI, Citation: "https://c2sp.org/static-ct-api", Description: "A static-ct-api log entry's leaf_index extension must match its position in the log", messages: []string{"Log entry leaf_index extension (%d) does not match the entry's position in the log (%d)"}},
+
+	// Log distrust impact analysis and CT Policy compliance timelines.
+	{Code: "no_embedded_scts", Severity: "I", Description: "The certificate contains no embedded SCTs", messages: []string{"Certificate contains no embedded SCTs"}},
+	{Code: "no_server_authentication_ct_policies", Severity: "I", Description: "No Server Authentication CT Policies apply to the certificate", messages: []string{"No Server Authentication CT Policies apply to this %s"}},
+	{Code: "impact_certificate_expires_first", Severity: "I", Description: "The certificate expires before the logs are distrusted", messages: []string{"Certificate expires before %s, so it is not affected"}},
+	{Code: "impact_no_scts_from_distrusted_logs", Severity: "I", Description: "The certificate does not depend on the distrusted logs", messages: []string{"Certificate contains no SCTs from the distrusted logs"}},
+	{Code: "impact_noncompliant_regardless", Severity: "I", Description: "The certificate would not comply with a CT Policy, even if the logs were not distrusted", messages: []string{"Certificate would not comply with the %s CT Policy at %s, regardless of the distrust"}},
+	{Code: "impact_compliance_lost", Severity: "W", Source: SourceCTPolicies, Citation: ctPoliciesCitation, Description: "The certificate would no longer comply with a CT Policy if the logs were distrusted", messages: []string{"Certificate would no longer comply with the %s CT Policy if the logs were %s at %s"}},
+	{Code: "impact_compliance_retained", Severity: "I", Description: "The certificate would still comply with a CT Policy if the logs were distrusted", messages: []string{"Certificate would still comply with the %s CT Policy if the logs were %s at %s"}},
+	{Code: "timeline_expired", Severity: "N", Description: "CT Policy compliance timelines are not evaluated for expired certificates", messages: []string{"Certificate has expired, so its CT Policy compliance timeline was not evaluated"}},
+	{Code: "timeline_not_compliant", Severity: "W", Source: SourceCTPolicies, Citation: ctPoliciesCitation, Description: "The certificate does not currently comply with a CT Policy", messages: []string{"Certificate does not currently comply with the %s CT Policy"}},
+	{Code: "timeline_compliance_ends", Severity: "W", Source: SourceCTPolicies, Citation: ctPoliciesCitation, Description: "The certificate would stop complying with a CT Policy before it expires", messages: []string{"Certificate would stop complying with the %s CT Policy at %s, when %s in the %s log list"}},
+	{Code: "timeline_temporal_interval_ends", Severity: "I", Description: "The temporal interval of a log that issued one of the certificate's SCTs ends before the certificate expires", messages: []string{"Certificate would still comply with the %s CT Policy at %s, when %s in the %s log list, but logs are usually retired soon after their temporal intervals end"}},
+	{Code: "timeline_compliant_until_expiry", Severity: "I", Description: "The certificate would comply with a CT Policy until it expires", messages: []string{"Certificate would comply with the %s CT Policy until it expires at %s"}},
+}
+
+func init() {
+	for _, l := range Lints {
+		for _, message := range l.messages {
+			if !lintFormatVerbPattern.MatchString(message) {
+				lintCodesByMessage[message] = append(lintCodesByMessage[message], l)
+				continue
+			}
+
+			// Each formatting verb matches any text.
+			var pattern strings.Builder
+			pattern.WriteString("^")
+			for i, literal := range lintFormatVerbPattern.Split(message, -1) {
+				if i > 0 {
+					pattern.WriteString(".*")
+				}
+				pattern.WriteString(regexp.QuoteMeta(literal))
+			}
+			pattern.WriteString("$")
+			lintMessagePatterns[l] = append(lintMessagePatterns[l], regexp.MustCompile(pattern.String()))
+			if len(lintMessagePatterns[l]) == 1 {
+				lintsWithVariableMessages = append(lintsWithVariableMessages, l)
+			}
+		}
+	}
+}
+
+// FindLint returns the Lint that reported a finding, or nil if the finding is not recognized.
+func FindLint(finding string) *Lint {
+	severity, message, found := strings.Cut(finding, ": ")
+	if !found {
+		return nil
+	}
+
+	candidates := lintCodesByMessage[message]
+	if len(candidates) == 0 {
+		for _, l := range lintsWithVariableMessages {
+			for _, pattern := range lintMessagePatterns[l] {
+				if pattern.MatchString(message) {
+					candidates = append(candidates, l)
+					break
+				}
+			}
+		}
+	}
+
+	// Where the same message is reported at different severities (e.g., the absence of embedded SCTs), the severity identifies the lint.  CT Policy findings that are downgraded to "I" keep the code of the lint that would otherwise have reported them.
+	for _, l := range candidates {
+		if l.Severity == severity {
+			return l
+		}
+	}
+	for _, l := range candidates {
+		if severity == "I" && (l.Severity == "E" || l.Severity == "W") {
+			return l
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return nil
+}
+
+// LintCode returns the stable code of the Lint that reported a finding, or "unknown" if the finding is not recognized.
+func LintCode(finding string) string {
+	if l := FindLint(finding); l != nil {
+		return l.Code
+	}
+	return "unknown"
+}
@@ -0,0 +1,113 @@
+package ctlint
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var findingLiteralPattern = regexp.MustCompile(`^([FEWNI]: [A-Za-z%"]|%s: [A-Z])`)
+
+// sourceFindings returns the format strings of the findings that are reported by the module's (non-test) source files, keyed by format string, with the files that report them.
+func sourceFindings(t *testing.T) map[string][]string {
+	t.Helper()
+
+	findings := make(map[string][]string)
+	err := filepath.WalkDir(".", func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if literal, ok := n.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+				if value, err := strconv.Unquote(literal.Value); err == nil && findingLiteralPattern.MatchString(value) {
+					findings[value] = append(findings[value], path)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return findings
+}
+
+func TestLintCatalogCoversFindings(t *testing.T) {
+	findings := sourceFindings(t)
+	if len(findings) < 100 {
+		t.Fatalf("only found %d findings in the source", len(findings))
+	}
+
+	var messages []string
+	for finding, paths := range findings {
+		severity, message, _ := strings.Cut(finding, ": ")
+		messages = append(messages, message)
+		if severity == "%s" {
+			// The severity is supplied at runtime (e.g., by ctPolicyFindingSeverity).
+			severity = "W"
+		}
+
+		// Substitute each formatting verb, as if the finding had been reported.
+		reported := severity + ": " + lintFormatVerbPattern.ReplaceAllString(message, "x")
+		if lint := FindLint(reported); lint == nil {
+			t.Errorf("%s: finding %q is not in the lint catalog", paths[0], finding)
+		}
+	}
+
+	codes := make(map[string]bool)
+	for _, lint := range Lints {
+		if codes[lint.Code] {
+			t.Errorf("lint code %q is not unique", lint.Code)
+		}
+		codes[lint.Code] = true
+		if !strings.Contains("FEWNI", lint.Severity) || len(lint.Severity) != 1 {
+			t.Errorf("lint %q has invalid severity %q", lint.Code, lint.Severity)
+		}
+
+		for _, message := range lint.messages {
+			found := false
+			for _, sourceMessage := range messages {
+				if sourceMessage == message {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("lint %q message %q is not reported by the source", lint.Code, message)
+			}
+		}
+	}
+}
+
+func TestLintCode(t *testing.T) {
+	for _, test := range []struct {
+		finding, want string
+	}{
+		{"E: SCT has an invalid signature purporting to be from Google 'Argon2026h1' log", "sct_invalid_signature"},
+		{"E: SCT has an invalid signature", "sct_invalid_signature"},
+		{"N: SCT list extension is absent in this Server Authentication certificate", "sct_list_absent"},
+		{"E: SCT list extension is absent in this Mark Certificate", "mark_certificate_sct_list_absent"},
+		{"I: SCT list contains fewer approved SCTs than required by the Chrome CT Policy", "sct_list_insufficient_scts"},
+		{"E: Log entry leaf_index extension (1) does not match the entry's position in the log (2)", "log_entry_leaf_index_mismatch"},
+		{"E: Something that ctlint never reports", "unknown"},
+		{"Not a finding", "unknown"},
+	} {
+		if got := LintCode(test.finding); got != test.want {
+			t.Errorf("LintCode(%q) = %q, want %q", test.finding, got, test.want)
+		}
+	}
+}
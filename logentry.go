@@ -0,0 +1,190 @@
+package ctlint
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// CheckLeafEntry checks a log entry as returned by an RFC6962 log's get-entries endpoint (leaf_input and extra_data).
+func CheckLeafEntry(index int64, leafEntry *ctgo.LeafEntry) []string {
+	rawLogEntry, err := ctgo.RawLogEntryFromLeaf(index, leafEntry)
+	if err != nil {
+		return []string{fmt.Sprintf("E: Log entry could not be decoded: %v", err)}
+	}
+
+	return CheckLogEntry(rawLogEntry)
+}
+
+// CheckLogEntry checks the certificate or precertificate in a log entry, using the entry's chain to identify its issuer, and checks that the entry's Merkle tree leaf is consistent with the certificate or precertificate that was submitted.
+func CheckLogEntry(rawLogEntry *ctgo.RawLogEntry) []string {
+	var findings []string
+
+	var chain []*x509.Certificate
+	for i, asn1Cert := range rawLogEntry.Chain {
+		chainCert, err := x509.ParseCertificate(asn1Cert.Data)
+		if x509.IsFatal(err) {
+			findings = append(findings, fmt.Sprintf("E: Log entry chain certificate #%d could not be parsed", i))
+			break
+		}
+		chain = append(chain, chainCert)
+	}
+
+	timestampedEntry := rawLogEntry.Leaf.TimestampedEntry
+	switch timestampedEntry.EntryType {
+	case ctgo.X509LogEntryType:
+		if timestampedEntry.X509Entry == nil {
+			return append(findings, "E: Log entry does not contain a certificate")
+		}
+		cert, err := x509.ParseCertificate(timestampedEntry.X509Entry.Data)
+		if x509.IsFatal(err) {
+			return append(findings, "E: Log entry certificate could not be parsed")
+		} else if cert.IsPrecertificate() {
+			findings = append(findings, "E: Log entry of type x509_entry contains a precertificate")
+		}
+
+		if len(chain) > 0 {
+			findings = append(findings, CheckCertificateWithIssuer(cert, chain[0])...)
+		} else {
+			findings = append(findings, CheckCertificate(cert, nil)...)
+		}
+
+	case ctgo.PrecertLogEntryType:
+		if timestampedEntry.PrecertEntry == nil {
+			return append(findings, "E: Log entry does not contain a precertificate")
+		}
+		precert, err := x509.ParseCertificate(rawLogEntry.Cert.Data)
+		if x509.IsFatal(err) {
+			return append(findings, "E: Log entry precertificate could not be parsed")
+		} else if len(chain) == 0 {
+			return append(findings, CheckPrecertificate(precert)...)
+		}
+
+		var caCert *x509.Certificate
+		if len(chain) > 1 {
+			caCert = chain[1]
+		}
+		findings = append(findings, CheckPrecertificateWithIssuer(precert, chain[0], caCert)...)
+		findings = append(findings, checkPrecertEntryConsistency(timestampedEntry.PrecertEntry, precert, chain)...)
+
+	default:
+		findings = append(findings, fmt.Sprintf("E: Log entry has unsupported entry type %v", timestampedEntry.EntryType))
+	}
+
+	return findings
+}
+
+// RFC6962 section 3.2: "issuer_key_hash is the SHA-256 hash of the certificate issuer's public key, calculated over the DER encoding of the key represented as SubjectPublicKeyInfo.  This is needed to bind the issuer to the final certificate.  tbs_certificate is the DER-encoded TBSCertificate (see [RFC5280]) component of the Precertificate -- that is, without the signature and the poison extension.  If the Precertificate is not signed with the CA certificate that will issue the final certificate, then the TBSCertificate also has its issuer changed to that of the CA that will issue the final certificate."
+func checkPrecertEntryConsistency(precertEntry *ctgo.PreCert, precert *x509.Certificate, chain []*x509.Certificate) []string {
+	var findings []string
+
+	var precertSigningCert *x509.Certificate
+	caCert := chain[0]
+	if chain[0].IsCA && hasCTEKU(chain[0]) {
+		precertSigningCert = chain[0]
+		if len(chain) < 2 {
+			return []string{"W: Log entry chain does not contain the CA certificate that issued the Precertificate Signing Certificate, so the issuer_key_hash cannot be checked"}
+		}
+		caCert = chain[1]
+	}
+
+	if sha256.Sum256(caCert.RawSubjectPublicKeyInfo) != precertEntry.IssuerKeyHash {
+		findings = append(findings, "E: Log entry issuer_key_hash does not match the key of the CA that will issue the final certificate")
+	}
+
+	if expectedTBS, err := x509.BuildPrecertTBS(precert.RawTBSCertificate, precertSigningCert); err != nil {
+		findings = append(findings, fmt.Sprintf("E: Expected log entry TBSCertificate could not be constructed: %v", err))
+	} else if !bytes.Equal(expectedTBS, precertEntry.TBSCertificate) {
+		findings = append(findings, "E: Log entry TBSCertificate does not match the submitted precertificate")
+	}
+
+	return findings
+}
+
+// maxRememberedPrecertEntries bounds the memory used by a LogEntrySCTChecker that follows a log indefinitely.
+const maxRememberedPrecertEntries = 1 << 16
+
+type rememberedPrecertEntry struct {
+	index int64
+	leaf  ctgo.MerkleTreeLeaf
+}
+
+// LogEntrySCTChecker checks consecutive entries from one log.  It remembers the log's recent precert_entry entries, so that when the corresponding certificate is subsequently logged to the same log, the SCT that the log issued for the precert_entry (which the CA should have embedded in the certificate) can be checked against that entry.
+type LogEntrySCTChecker struct {
+	precertEntries map[[sha256.Size]byte]rememberedPrecertEntry
+}
+
+func NewLogEntrySCTChecker() *LogEntrySCTChecker {
+	return &LogEntrySCTChecker{precertEntries: make(map[[sha256.Size]byte]rememberedPrecertEntry)}
+}
+
+// Check checks the SCTs embedded in the certificate in an x509_entry against the log's precert_entry for the corresponding precertificate, if that entry was previously checked.
+// An SCT's timestamp and extensions are also those of the log entry that the log created for it (RFC6962 section 3.4), and an SCT that is embedded in a certificate must have been issued for a precert_entry.
+func (c *LogEntrySCTChecker) Check(rawLogEntry *ctgo.RawLogEntry) []string {
+	timestampedEntry := rawLogEntry.Leaf.TimestampedEntry
+	switch {
+	case timestampedEntry == nil:
+		return nil
+	case timestampedEntry.EntryType == ctgo.PrecertLogEntryType && timestampedEntry.PrecertEntry != nil:
+		if len(c.precertEntries) >= maxRememberedPrecertEntries {
+			clear(c.precertEntries)
+		}
+		c.precertEntries[precertEntryKey(timestampedEntry.PrecertEntry.IssuerKeyHash, timestampedEntry.PrecertEntry.TBSCertificate)] = rememberedPrecertEntry{index: rawLogEntry.Index, leaf: rawLogEntry.Leaf}
+		return nil
+	case timestampedEntry.EntryType != ctgo.X509LogEntryType || timestampedEntry.X509Entry == nil || len(rawLogEntry.Chain) == 0:
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(timestampedEntry.X509Entry.Data)
+	if x509.IsFatal(err) {
+		return nil
+	}
+	issuer, err := x509.ParseCertificate(rawLogEntry.Chain[0].Data)
+	if x509.IsFatal(err) {
+		return nil
+	}
+
+	var scts []*ctgo.SignedCertificateTimestamp
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(x509.OIDExtensionCTSCT) {
+			scts, _ = parseSCTListExtension(ext)
+		}
+	}
+	if len(scts) == 0 {
+		return nil
+	}
+
+	tbsCert, err := x509.RemoveSCTList(cert.RawTBSCertificate)
+	if err != nil {
+		return nil
+	}
+	precertEntry, found := c.precertEntries[precertEntryKey(sha256.Sum256(issuer.RawSubjectPublicKeyInfo), tbsCert)]
+	if !found {
+		for _, sct := range scts {
+			if sct.Timestamp == timestampedEntry.Timestamp {
+				return []string{"E: Certificate embeds an SCT with the timestamp of its own x509_entry, but embedded SCTs must be issued for a precert_entry"}
+			}
+		}
+		return nil
+	}
+
+	for _, sct := range scts {
+		if sct.Timestamp != precertEntry.leaf.TimestampedEntry.Timestamp {
+			continue
+		} else if !bytes.Equal(sct.Extensions, precertEntry.leaf.TimestampedEntry.Extensions) {
+			return []string{fmt.Sprintf("E: Embedded SCT has the timestamp of the log's precert_entry for this certificate (at index %d), but different extensions", precertEntry.index)}
+		} else if timestampedEntry.Timestamp < sct.Timestamp {
+			return []string{fmt.Sprintf("E: Log entry timestamp is before the timestamp of the log's precert_entry for this certificate (at index %d)", precertEntry.index)}
+		}
+		return nil
+	}
+
+	return []string{fmt.Sprintf("N: Certificate does not embed the SCT that the log issued for its precert_entry (at index %d)", precertEntry.index)}
+}
+
+func precertEntryKey(issuerKeyHash [sha256.Size]byte, tbsCertificate []byte) [sha256.Size]byte {
+	return sha256.Sum256(append(issuerKeyHash[:], tbsCertificate...))
+}
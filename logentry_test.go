@@ -0,0 +1,132 @@
+package ctlint
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	encasn1 "encoding/asn1"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/tls"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// newTestCertificate issues a certificate containing the specified SCTs (or, if precert is true, a precertificate) from a new self-signed CA, and returns the certificate and the CA certificate.
+func newTestCertificate(t testing.TB, scts []ctgo.SignedCertificateTimestamp, precert bool) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test CA"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(365 * 24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: stdx509.KeyUsageCertSign}
+	caDER, err := stdx509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := stdx509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &stdx509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.com"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(90 * 24 * time.Hour), ExtKeyUsage: []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth}}
+	if precert {
+		template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: encasn1.ObjectIdentifier(x509.OIDExtensionCTPoison), Critical: true, Value: []byte{0x05, 0x00}})
+	}
+	if len(scts) > 0 {
+		var sctList x509.SignedCertificateTimestampList
+		for _, sct := range scts {
+			serialized, err := tls.Marshal(sct)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sctList.SCTList = append(sctList.SCTList, x509.SerializedSCT{Val: serialized})
+		}
+		sctListValue, err := tls.Marshal(sctList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		extValue, err := encasn1.Marshal(sctListValue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, pkix.Extension{Id: encasn1.ObjectIdentifier(x509.OIDExtensionCTSCT), Value: extValue})
+	}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, caCert, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, issuer
+}
+
+func TestLogEntrySCTChecker(t *testing.T) {
+	timestamp := uint64(time.Now().Add(-time.Minute).UnixMilli())
+	extensions := ctgo.CTExtensions{0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x2a}
+	sct := ctgo.SignedCertificateTimestamp{SCTVersion: ctgo.V1, Timestamp: timestamp, Extensions: extensions, Signature: ctgo.DigitallySigned{Algorithm: tls.SignatureAndHashAlgorithm{Hash: tls.SHA256, Signature: tls.ECDSA}, Signature: []byte{0x00}}}
+
+	cert, issuer := newTestCertificate(t, []ctgo.SignedCertificateTimestamp{sct}, false)
+	tbsCert, err := x509.RemoveSCTList(cert.RawTBSCertificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	precertEntry := func(timestamp uint64, extensions ctgo.CTExtensions) *ctgo.RawLogEntry {
+		return &ctgo.RawLogEntry{Index: 42, Leaf: ctgo.MerkleTreeLeaf{TimestampedEntry: &ctgo.TimestampedEntry{
+			EntryType:    ctgo.PrecertLogEntryType,
+			Timestamp:    timestamp,
+			PrecertEntry: &ctgo.PreCert{IssuerKeyHash: sha256.Sum256(issuer.RawSubjectPublicKeyInfo), TBSCertificate: tbsCert},
+			Extensions:   extensions,
+		}}}
+	}
+	x509Entry := func(timestamp uint64) *ctgo.RawLogEntry {
+		return &ctgo.RawLogEntry{Index: 43, Leaf: ctgo.MerkleTreeLeaf{TimestampedEntry: &ctgo.TimestampedEntry{
+			EntryType: ctgo.X509LogEntryType,
+			Timestamp: timestamp,
+			X509Entry: &ctgo.ASN1Cert{Data: cert.Raw},
+		}}, Chain: []ctgo.ASN1Cert{{Data: issuer.Raw}}}
+	}
+
+	for _, test := range []struct {
+		name    string
+		entries []*ctgo.RawLogEntry
+		want    string
+	}{
+		{"consistent", []*ctgo.RawLogEntry{precertEntry(timestamp, extensions), x509Entry(timestamp + 1000)}, ""},
+		{"different extensions", []*ctgo.RawLogEntry{precertEntry(timestamp, nil), x509Entry(timestamp + 1000)}, "E: Embedded SCT has the timestamp of the log's precert_entry for this certificate (at index 42), but different extensions"},
+		{"certificate logged first", []*ctgo.RawLogEntry{precertEntry(timestamp, extensions), x509Entry(timestamp - 1000)}, "E: Log entry timestamp is before the timestamp of the log's precert_entry for this certificate (at index 42)"},
+		{"SCT from another log", []*ctgo.RawLogEntry{precertEntry(timestamp+1, extensions), x509Entry(timestamp + 1000)}, "N: Certificate does not embed the SCT that the log issued for its precert_entry (at index 42)"},
+		{"SCT for x509_entry", []*ctgo.RawLogEntry{x509Entry(timestamp)}, "E: Certificate embeds an SCT with the timestamp of its own x509_entry, but embedded SCTs must be issued for a precert_entry"},
+		{"no precert_entry", []*ctgo.RawLogEntry{x509Entry(timestamp + 1000)}, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			checker := NewLogEntrySCTChecker()
+			var findings []string
+			for _, entry := range test.entries {
+				findings = append(findings, checker.Check(entry)...)
+			}
+
+			if test.want == "" && len(findings) > 0 {
+				t.Errorf("unexpected findings:\n%s", strings.Join(findings, "\n"))
+			} else if test.want != "" && !slices.Contains(findings, test.want) {
+				t.Errorf("missing %q in findings:\n%s", test.want, strings.Join(findings, "\n"))
+			}
+		})
+	}
+}
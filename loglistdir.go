@@ -0,0 +1,227 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/loglist3"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// The files in a log list directory, as written by "ctlint update-loglists".  Each file is optional.
+const (
+	ChromeLogListFilename          = "chrome/all_logs_list.json"
+	ChromeLogListSignatureFilename = "chrome/all_logs_list.sig"
+	AppleLogListFilename           = "apple/current_log_list.json"
+	MozillaKnownLogsFilename       = "mozilla/CTKnownLogs.h"
+	BIMILogListFilename            = "bimi/log_list.json"
+)
+
+// GoogleLogListPublicKeyPEM is Google's PEM-encoded log list signing key.  The Chrome log list is only accepted if its signature verifies using this key, both when "ctlint update-loglists" downloads it and whenever it is loaded from a log list directory, so that a log list cannot be substituted by anyone who can serve or write it.
+//
+//go:embed files/google_log_list_pubkey.pem
+var GoogleLogListPublicKeyPEM []byte
+
+// LogListFiles identifies log list files that are preferred over the log lists embedded in ctloglists, such as those that a particular browser release enforces its CT Policy against.
+type LogListFiles struct {
+	Dir              string // A log list directory, as written by "ctlint update-loglists" (see LoadLogListDir).
+	FirefoxKnownLogs string // Firefox's CTKnownLogs.h, which is preferred over the Mozilla log list in Dir.
+	AppleLogList     string // Apple's current_log_list.json, which is preferred over the Apple log list in Dir.
+}
+
+type logListOverride struct {
+	logList *loglist3.LogList
+	source  string
+}
+
+// load loads the log list files, keyed by name, along with a description of each log list's source.
+func (files LogListFiles) load() (map[string]logListOverride, error) {
+	overrides := make(map[string]logListOverride)
+	if files.Dir != "" {
+		logLists, err := LoadLogListDir(files.Dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, logListFile := range logListDirFiles {
+			if logList, found := logLists[logListFile.name]; found {
+				overrides[logListFile.name] = logListOverride{logList: logList, source: describeLogListSource(filepath.Join(files.Dir, filepath.FromSlash(logListFile.filename)), logList)}
+			}
+		}
+	}
+
+	for _, logListFile := range []struct {
+		name     string
+		filename string
+		load     func(filename string) (*loglist3.LogList, error)
+	}{
+		{"Mozilla", files.FirefoxKnownLogs, LoadFirefoxKnownLogs},
+		{"Apple", files.AppleLogList, LoadAppleLogList},
+	} {
+		if logListFile.filename == "" {
+			continue
+		}
+		logList, err := logListFile.load(logListFile.filename)
+		if err != nil {
+			return nil, err
+		}
+		overrides[logListFile.name] = logListOverride{logList: logList, source: describeLogListSource(logListFile.filename, logList)}
+	}
+
+	return overrides, nil
+}
+
+// describeLogListSource identifies a log list by the file or directory it was loaded from, and by its version and timestamp, so that findings can report precisely which (e.g., browser release's) log list a CT Policy was evaluated against.
+func describeLogListSource(path string, logList *loglist3.LogList) string {
+	description := path
+	if logList.Version != "" {
+		description += ", version " + logList.Version
+	}
+	return description + ", log_list_timestamp " + logList.LogListTimestamp.UTC().Format(time.RFC3339)
+}
+
+// LoadFirefoxKnownLogs loads the log list that a Firefox release enforces the Mozilla CT Policy against, from that release's CTKnownLogs.h (see ParseCTKnownLogs).
+func LoadFirefoxKnownLogs(filename string) (*loglist3.LogList, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	logList, err := ParseCTKnownLogs(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return logList, nil
+}
+
+// LoadAppleLogList loads the log list that Apple platforms enforce the Apple CT Policy against, from Apple's current_log_list.json (see ParseAppleLogList).
+func LoadAppleLogList(filename string) (*loglist3.LogList, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	logList, err := ParseAppleLogList(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return logList, nil
+}
+
+// ParseAppleLogList parses Apple's current_log_list.json, which uses the same schema as Chrome's v3 log lists.
+func ParseAppleLogList(data []byte) (*loglist3.LogList, error) {
+	logList, err := loglist3.NewFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operator := range logList.Operators {
+		if len(operator.Logs) > 0 || len(operator.TiledLogs) > 0 {
+			return logList, nil
+		}
+	}
+	return nil, errors.New("log list contains no logs")
+}
+
+// logListDirFiles lists the log list files in a log list directory, and how to parse each of them.
+var logListDirFiles = []struct {
+	name     string
+	filename string
+	parse    func(data []byte) (*loglist3.LogList, error) // nil for the Chrome log list, whose signature is verified (see parseChromeLogListFile).
+}{
+	{"Chrome", ChromeLogListFilename, nil},
+	{"Apple", AppleLogListFilename, ParseAppleLogList},
+	{"Mozilla", MozillaKnownLogsFilename, ParseCTKnownLogs},
+	{"BIMI", BIMILogListFilename, loglist3.NewFromJSON},
+}
+
+// LoadLogListDir loads the log lists that have been saved to dir, keyed by name ("Chrome", "Apple", "Mozilla", and "BIMI").  Log lists that are absent from dir are omitted.
+// The Chrome log list is only loaded if its signature is also present and verifies using GoogleLogListPublicKeyPEM.
+func LoadLogListDir(dir string) (map[string]*loglist3.LogList, error) {
+	logLists := make(map[string]*loglist3.LogList)
+	for _, logListFile := range logListDirFiles {
+		filename := filepath.Join(dir, filepath.FromSlash(logListFile.filename))
+		data, err := os.ReadFile(filename)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		parse := logListFile.parse
+		if parse == nil {
+			parse = func(data []byte) (*loglist3.LogList, error) { return parseChromeLogListFile(dir, data) }
+		}
+		if logLists[logListFile.name], err = parse(data); err != nil {
+			return nil, fmt.Errorf("%s: %v", filename, err)
+		}
+	}
+
+	return logLists, nil
+}
+
+// parseChromeLogListFile verifies and parses the Chrome log list in dir.  An unsigned Chrome log list is rejected.
+func parseChromeLogListFile(dir string, data []byte) (*loglist3.LogList, error) {
+	signatureFilename := filepath.Join(dir, filepath.FromSlash(ChromeLogListSignatureFilename))
+	signature, err := os.ReadFile(signatureFilename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("signature cannot be verified, because %s is missing", signatureFilename)
+	} else if err != nil {
+		return nil, err
+	}
+
+	return VerifyChromeLogList(data, signature, GoogleLogListPublicKeyPEM)
+}
+
+// VerifyChromeLogList verifies the signature over a Chrome log list, using Google's PEM-encoded log list public key, and parses the log list.
+func VerifyChromeLogList(data, signature, publicKeyPEM []byte) (*loglist3.LogList, error) {
+	if block, _ := pem.Decode(publicKeyPEM); block == nil {
+		return nil, errors.New("log list public key is not PEM-encoded")
+	}
+	publicKey, _, _, err := ctgo.PublicKeyFromPEM(publicKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("log list public key could not be parsed: %v", err)
+	}
+
+	return loglist3.NewFromSignedJSON(data, signature, publicKey)
+}
+
+// addLogData adds the signature verifier and temporal interval of each log in logList that is not already known, so that SCTs from logs that were added to a log list after ctloglists was released can be verified.
+func addLogData(logList *loglist3.LogList, signatureVerifiers map[[sha256.Size]byte]*ctgo.SignatureVerifier, temporalIntervals map[[sha256.Size]byte]*loglist3.TemporalInterval) {
+	add := func(logID, key []byte, temporalInterval *loglist3.TemporalInterval) {
+		if len(logID) != sha256.Size {
+			return
+		} else if _, found := signatureVerifiers[[sha256.Size]byte(logID)]; found {
+			return
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(key)
+		if err != nil {
+			return
+		}
+		signatureVerifier, err := ctgo.NewSignatureVerifier(publicKey)
+		if err != nil {
+			return
+		}
+
+		signatureVerifiers[[sha256.Size]byte(logID)] = signatureVerifier
+		if temporalInterval != nil {
+			temporalIntervals[[sha256.Size]byte(logID)] = temporalInterval
+		}
+	}
+
+	for _, operator := range logList.Operators {
+		for _, log := range operator.Logs {
+			add(log.LogID, log.Key, log.TemporalInterval)
+		}
+		for _, tiledLog := range operator.TiledLogs {
+			add(tiledLog.LogID, tiledLog.Key, tiledLog.TemporalInterval)
+		}
+	}
+}
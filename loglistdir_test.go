@@ -0,0 +1,30 @@
+package ctlint
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"testing"
+
+	ctgo "github.com/google/certificate-transparency-go"
+)
+
+// TestGoogleLogListPublicKey checks the log list public key as shipped, since every Chrome log list is rejected if it cannot be parsed.
+func TestGoogleLogListPublicKey(t *testing.T) {
+	publicKey, _, rest, err := ctgo.PublicKeyFromPEM(GoogleLogListPublicKeyPEM)
+	if err != nil {
+		t.Fatalf("files/google_log_list_pubkey.pem does not contain Google's log list public key: %v", err)
+	}
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.N.BitLen() < 2048 {
+			t.Errorf("log list public key is a %d-bit RSA key", publicKey.N.BitLen())
+		}
+	case *ecdsa.PublicKey:
+	default:
+		t.Errorf("log list public key is a %T", publicKey)
+	}
+	if len(rest) != 0 {
+		t.Error("files/google_log_list_pubkey.pem contains more than one PEM block")
+	}
+}
@@ -0,0 +1,92 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"maps"
+
+	ctgo "github.com/google/certificate-transparency-go"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+)
+
+// indexedLog is a log from a log list, along with the name of its operator (as it appears in that log list) and the operator's canonical identifier.
+type indexedLog struct {
+	*loglist3.Log
+	operatorName string
+	operatorID   string
+	isRFC6962Log bool
+}
+
+// logListIndex maps the log IDs in a log list to its logs, so that the log that issued an SCT can be found without scanning every operator's logs.
+type logListIndex struct {
+	logList *loglist3.LogList
+	source  string // Describes the log list file that was loaded instead of the embedded log list, if any.
+	logs    map[[sha256.Size]byte]*indexedLog
+}
+
+func newLogListIndex(logList *loglist3.LogList) *logListIndex {
+	index := &logListIndex{logList: logList, logs: make(map[[sha256.Size]byte]*indexedLog)}
+	if logList == nil {
+		return index
+	}
+
+	// If a log ID appears more than once, the first RFC6962 log with that ID takes precedence, followed by the first static-ct-api log.
+	for _, operator := range logList.Operators {
+		for _, log := range operator.Logs {
+			if len(log.LogID) != sha256.Size {
+				continue
+			} else if _, found := index.logs[[sha256.Size]byte(log.LogID)]; !found {
+				index.logs[[sha256.Size]byte(log.LogID)] = &indexedLog{Log: log, operatorName: operator.Name, operatorID: canonicalLogOperatorID(operator.Name), isRFC6962Log: true}
+			}
+		}
+	}
+
+	for _, operator := range logList.Operators {
+		for _, tiledLog := range operator.TiledLogs {
+			if len(tiledLog.LogID) != sha256.Size {
+				continue
+			} else if _, found := index.logs[[sha256.Size]byte(tiledLog.LogID)]; !found {
+				index.logs[[sha256.Size]byte(tiledLog.LogID)] = &indexedLog{
+					Log: &loglist3.Log{
+						State:            tiledLog.State,
+						TemporalInterval: tiledLog.TemporalInterval,
+						Description:      tiledLog.Description,
+						Type:             tiledLog.Type,
+					},
+					operatorName: operator.Name,
+					operatorID:   canonicalLogOperatorID(operator.Name),
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// findLog returns the log with the specified log ID, or nil if the log list does not contain it.
+func (index *logListIndex) findLog(logID [sha256.Size]byte) *indexedLog {
+	return index.logs[logID]
+}
+
+// findLogs returns the log that issued each SCT, or nil for each SCT whose log the log list does not contain.
+func (index *logListIndex) findLogs(scts []*ctgo.SignedCertificateTimestamp) []*indexedLog {
+	ctLogs := make([]*indexedLog, len(scts))
+	for i, sct := range scts {
+		ctLogs[i] = index.findLog(sct.LogID.KeyID)
+	}
+	return ctLogs
+}
+
+// withLogStates returns a copy of the index in which the state of each of the specified logs that the log list contains is replaced by newState(its current state).  The log list itself is not modified.
+func (index *logListIndex) withLogStates(logIDs [][sha256.Size]byte, newState func(state *loglist3.LogStates) *loglist3.LogStates) *logListIndex {
+	modifiedIndex := &logListIndex{logList: index.logList, source: index.source, logs: maps.Clone(index.logs)}
+	for _, logID := range logIDs {
+		if ctLog := modifiedIndex.logs[logID]; ctLog != nil {
+			modifiedLog := *ctLog.Log
+			modifiedLog.State = newState(ctLog.State)
+			modifiedIndex.logs[logID] = &indexedLog{Log: &modifiedLog, operatorName: ctLog.operatorName, operatorID: ctLog.operatorID, isRFC6962Log: ctLog.isRFC6962Log}
+		}
+	}
+
+	return modifiedIndex
+}
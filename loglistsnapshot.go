@@ -0,0 +1,170 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/crtsh/ctloglists"
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/loglist3"
+)
+
+// LogListSnapshot is an immutable copy of the log lists, and of the log data derived from them, that SCTs are checked against.  Each check captures the current snapshot when it starts, so that reloading the log lists never affects a check that is already in progress.
+type LogListSnapshot struct {
+	version                                     uint64
+	loadedAt                                    time.Time
+	chrome, apple, mozilla, bimi, crtsh, mimics *logListIndex
+	temporalIntervals                           map[[sha256.Size]byte]*loglist3.TemporalInterval
+	signatureVerifiers                          map[[sha256.Size]byte]*ctgo.SignatureVerifier
+	operators                                   map[string]*LogOperator
+	resolvedLogs                                map[[sha256.Size]byte]*resolvedLog
+}
+
+var (
+	currentLogListSnapshot atomic.Pointer[LogListSnapshot]
+	logListSnapshotMutex   sync.Mutex // Serializes the (re-)loading of ctloglists' package-level log lists, and the building of snapshots from them.
+	logListSnapshotVersion uint64
+)
+
+// ReloadLogLists (re-)loads the log lists using ctloglists.LoadLogLists, and atomically replaces the current snapshot with one that contains them.  If loading fails, the current snapshot remains in use.
+// If log list files are specified, they are preferred over the log lists embedded in ctloglists.
+func ReloadLogLists(logListFiles_optional ...LogListFiles) (*LogListSnapshot, error) {
+	logListSnapshotMutex.Lock()
+	defer logListSnapshotMutex.Unlock()
+
+	if err := ctloglists.LoadLogLists(); err != nil {
+		return nil, err
+	}
+
+	var overrides map[string]logListOverride
+	if len(logListFiles_optional) > 0 {
+		var err error
+		if overrides, err = logListFiles_optional[0].load(); err != nil {
+			return nil, err
+		}
+	}
+
+	logListSnapshotVersion++
+	snapshot := newLogListSnapshot(logListSnapshotVersion, overrides)
+	currentLogListSnapshot.Store(snapshot)
+	return snapshot, nil
+}
+
+// CurrentLogListSnapshot returns the most recently loaded log list snapshot.  If ReloadLogLists has never been called, the snapshot is built from whichever log lists the application has already loaded using ctloglists.LoadLogLists.
+func CurrentLogListSnapshot() *LogListSnapshot {
+	if snapshot := currentLogListSnapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	logListSnapshotMutex.Lock()
+	defer logListSnapshotMutex.Unlock()
+	if snapshot := currentLogListSnapshot.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	// Until the log lists have been loaded, don't retain an empty snapshot (which has version 0), so that the log lists are picked up once they are loaded.
+	if ctloglists.GstaticV3All == nil {
+		return newLogListSnapshot(0, nil)
+	}
+
+	logListSnapshotVersion++
+	snapshot := newLogListSnapshot(logListSnapshotVersion, nil)
+	currentLogListSnapshot.Store(snapshot)
+	return snapshot
+}
+
+// newLogListSnapshot builds a snapshot from ctloglists' package-level log lists, which must not be modified until it returns, except that the log lists in overrides (keyed by name, as returned by LogLists) take precedence.
+func newLogListSnapshot(version uint64, overrides map[string]logListOverride) *LogListSnapshot {
+	newIndex := func(name string, logList *loglist3.LogList) *logListIndex {
+		override, found := overrides[name]
+		if !found {
+			return newLogListIndex(logList)
+		}
+		index := newLogListIndex(override.logList)
+		index.source = override.source
+		return index
+	}
+
+	snapshot := &LogListSnapshot{
+		version:            version,
+		loadedAt:           time.Now(),
+		chrome:             newIndex("Chrome", ctloglists.GstaticV3All),
+		apple:              newIndex("Apple", ctloglists.AppleCurrent),
+		mozilla:            newIndex("Mozilla", ctloglists.MozillaV3Known),
+		bimi:               newIndex("BIMI", ctloglists.BimiV3Approved),
+		crtsh:              newIndex("crt.sh", ctloglists.CrtshV3All),
+		mimics:             newLogListIndex(ctloglists.LogMimics),
+		temporalIntervals:  maps.Clone(ctloglists.TemporalIntervalMap),
+		signatureVerifiers: maps.Clone(ctloglists.LogSignatureVerifierMap),
+	}
+	if snapshot.temporalIntervals == nil {
+		snapshot.temporalIntervals = make(map[[sha256.Size]byte]*loglist3.TemporalInterval)
+	}
+	if snapshot.signatureVerifiers == nil {
+		snapshot.signatureVerifiers = make(map[[sha256.Size]byte]*ctgo.SignatureVerifier)
+	}
+	for _, override := range overrides {
+		addLogData(override.logList, snapshot.signatureVerifiers, snapshot.temporalIntervals)
+	}
+	snapshot.operators = newLogOperators(map[string]*logListIndex{
+		"Chrome":  snapshot.chrome,
+		"Apple":   snapshot.apple,
+		"Mozilla": snapshot.mozilla,
+		"BIMI":    snapshot.bimi,
+		"crt.sh":  snapshot.crtsh,
+	})
+	snapshot.resolvedLogs = snapshot.resolveLogs()
+
+	return snapshot
+}
+
+// Version returns the snapshot's version number, which increases each time the log lists are (re-)loaded.
+func (snapshot *LogListSnapshot) Version() uint64 {
+	return snapshot.version
+}
+
+// LoadedAt returns the time at which the snapshot was built.
+func (snapshot *LogListSnapshot) LoadedAt() time.Time {
+	return snapshot.loadedAt
+}
+
+// LogLists returns the snapshot's log lists, keyed by name ("Chrome", "Apple", "Mozilla", "BIMI", and "crt.sh").  A log list that could not be loaded is nil.
+func (snapshot *LogListSnapshot) LogLists() map[string]*loglist3.LogList {
+	return map[string]*loglist3.LogList{
+		"Chrome":  snapshot.chrome.logList,
+		"Apple":   snapshot.apple.logList,
+		"Mozilla": snapshot.mozilla.logList,
+		"BIMI":    snapshot.bimi.logList,
+		"crt.sh":  snapshot.crtsh.logList,
+	}
+}
+
+// LogOperators returns the log operators that appear in the snapshot's log lists, keyed by canonical identifier.
+func (snapshot *LogListSnapshot) LogOperators() map[string]*LogOperator {
+	return snapshot.operators
+}
+
+// describeLog returns a description of the log with the specified log ID, for display purposes, with its operator's name prepended (if not already present).
+func (snapshot *LogListSnapshot) describeLog(logID [sha256.Size]byte) (string, bool) {
+	description := snapshot.resolveLog(logID).description
+	return description, description != ""
+}
+
+// IsKnownLogDescription reports whether a log description, as it appears in findings, belongs to one of the snapshot's logs.  Metrics use this to ensure that log labels are taken from the log lists, rather than from arbitrary text.
+func (snapshot *LogListSnapshot) IsKnownLogDescription(description string) bool {
+	for _, log := range snapshot.resolvedLogs {
+		if log.description == description {
+			return true
+		}
+		for _, ctLog := range []*indexedLog{log.crtsh, log.chrome, log.mimics} {
+			if ctLog != nil && ctLog.Description == description {
+				return true
+			}
+		}
+	}
+
+	return false
+}
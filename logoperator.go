@@ -0,0 +1,86 @@
+package ctlint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// LogOperator identifies a log operator across the log lists, which do not always agree on (or keep using) the same name for it.
+type LogOperator struct {
+	ID      string            // Canonical identifier, derived from the operator's name (see canonicalLogOperatorID).
+	Aliases []string          // Every name under which the operator appears in any of the log lists, sorted.
+	Names   map[string]string // The operator's name in each log list that contains it, keyed by log list name (as returned by LogListSnapshot.LogLists).
+}
+
+// logOperatorAliases maps the canonical identifiers of former or alternative operator names to the canonical identifier of the operator's current name.
+var logOperatorAliases = map[string]string{
+	"comodo":                        "sectigo",
+	"comodoca":                      "sectigo",
+	"isrg":                          "letsencrypt",
+	"internetsecurityresearchgroup": "letsencrypt",
+}
+
+// logOperatorNameSuffixes are words that are ignored at the end of an operator's name, so that (e.g.) "DigiCert" and "DigiCert, Inc." are recognized as the same operator.
+var logOperatorNameSuffixes = []string{"co", "corp", "corporation", "gmbh", "inc", "limited", "llc", "ltd"}
+
+// canonicalLogOperatorID derives an operator's canonical identifier from its name, by lower-casing it, ignoring punctuation and company suffixes, and resolving known aliases.
+func canonicalLogOperatorID(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(strings.ReplaceAll(name, "'", "")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for len(words) > 1 && slices.Contains(logOperatorNameSuffixes, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+
+	id := strings.Join(words, "")
+	if alias, found := logOperatorAliases[id]; found {
+		return alias
+	}
+	return id
+}
+
+// newLogOperators builds the operator identity model from the named log list indexes, keyed by canonical identifier.
+func newLogOperators(indexes map[string]*logListIndex) map[string]*LogOperator {
+	operators := make(map[string]*LogOperator)
+	for name, index := range indexes {
+		if index.logList == nil {
+			continue
+		}
+		for _, operator := range index.logList.Operators {
+			id := canonicalLogOperatorID(operator.Name)
+			if operators[id] == nil {
+				operators[id] = &LogOperator{ID: id, Names: make(map[string]string)}
+			}
+			operators[id].Names[name] = operator.Name
+			if !slices.Contains(operators[id].Aliases, operator.Name) {
+				operators[id].Aliases = append(operators[id].Aliases, operator.Name)
+			}
+		}
+	}
+
+	for _, operator := range operators {
+		slices.Sort(operator.Aliases)
+	}
+	return operators
+}
+
+// checkLogOperatorAttribution checks that the log lists that contain a log agree on which operator operates it.
+func checkLogOperatorAttribution(log *resolvedLog) []string {
+	var attributions []string
+	var operatorIDs []string
+	for _, name := range []string{"Chrome", "Apple", "Mozilla", "BIMI", "crt.sh"} {
+		if ctLog := log.inLogList(name); ctLog != nil {
+			attributions = append(attributions, fmt.Sprintf("%s says %q", name, ctLog.operatorName))
+			if !slices.Contains(operatorIDs, ctLog.operatorID) {
+				operatorIDs = append(operatorIDs, ctLog.operatorID)
+			}
+		}
+	}
+
+	if len(operatorIDs) > 1 {
+		return []string{fmt.Sprintf("N: Log lists disagree on the operator of %s: %s", log.description, strings.Join(attributions, ", "))}
+	}
+	return nil
+}
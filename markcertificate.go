@@ -0,0 +1,105 @@
+package ctlint
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/google/certificate-transparency-go/asn1"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// RFC3709 section 4.1: "id-pe-logotype OBJECT IDENTIFIER ::= { id-pe 12 }"
+var OIDExtensionLogotype = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 12}
+
+var OIDAttributeCountryName = asn1.ObjectIdentifier{2, 5, 4, 6}
+var OIDAttributeOrganizationName = asn1.ObjectIdentifier{2, 5, 4, 10}
+var OIDAttributeMarkType = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 53087, 1, 13}
+
+// Subject attributes that the Mark Certificate Guidelines require in every Mark Certificate.
+var markCertificateRequiredSubjectAttributes = []struct {
+	oid  asn1.ObjectIdentifier
+	name string
+}{
+	{OIDAttributeOrganizationName, "organizationName"},
+	{OIDAttributeCountryName, "countryName"},
+	{OIDAttributeMarkType, "markType"},
+}
+
+// Mark Certificate Guidelines: "Before issuance of a Mark Certificate, the CA SHALL log the Mark Certificate pre-certificate (including all the data included in the Subject field of the certificate plus the Mark Representation) to one or more public CT logs."
+func checkMarkCertificatePrecertificate(precert *x509.Certificate) []string {
+	var findings []string
+
+	for _, attribute := range markCertificateRequiredSubjectAttributes {
+		if !hasSubjectAttribute(precert, attribute.oid) {
+			findings = append(findings, fmt.Sprintf("E: Mark Certificate precertificate Subject does not include the %s attribute", attribute.name))
+		}
+	}
+
+	logotypeExtCount := 0
+	for _, ext := range precert.Extensions {
+		if ext.Id.Equal(OIDExtensionLogotype) {
+			logotypeExtCount++
+			if logotypeExtCount > 1 {
+				findings = append(findings, "E: Multiple logotype extensions are present")
+			}
+			var logotypeExtn asn1.RawValue
+			if rest, err := asn1.Unmarshal(ext.Value, &logotypeExtn); err != nil || logotypeExtn.Tag != asn1.TagSequence || !logotypeExtn.IsCompound {
+				findings = append(findings, "E: Logotype extension could not be parsed")
+			} else if len(rest) != 0 {
+				findings = append(findings, "E: Logotype extension contains trailing data")
+			}
+		}
+	}
+
+	if logotypeExtCount == 0 {
+		findings = append(findings, "E: Mark Certificate precertificate does not include the Mark Representation (logotype extension is absent)")
+	}
+
+	return findings
+}
+
+// CheckMarkCertificatePrecertificateConsistency checks that the Subject data and the Mark Representation that were logged in a Mark Certificate precertificate exactly match those in the corresponding Mark Certificate.
+func CheckMarkCertificatePrecertificateConsistency(cert *x509.Certificate, precert *x509.Certificate) []string {
+	if cert == nil {
+		return []string{"E: Certificate not provided"}
+	} else if precert == nil {
+		return []string{"E: Precertificate not provided"}
+	}
+
+	var findings []string
+	if !bytes.Equal(cert.RawSubject, precert.RawSubject) {
+		findings = append(findings, "E: Mark Certificate Subject does not exactly match the precertificate Subject")
+	}
+
+	certLogotype, certHasLogotype := findExtensionValue(cert, OIDExtensionLogotype)
+	precertLogotype, precertHasLogotype := findExtensionValue(precert, OIDExtensionLogotype)
+	if !certHasLogotype {
+		findings = append(findings, "E: Mark Certificate logotype extension is absent")
+	} else if !precertHasLogotype {
+		findings = append(findings, "E: Mark Certificate precertificate logotype extension is absent")
+	} else if !bytes.Equal(certLogotype, precertLogotype) {
+		findings = append(findings, "E: Mark Certificate logotype extension does not exactly match the precertificate logotype extension")
+	}
+
+	return findings
+}
+
+func hasSubjectAttribute(cert *x509.Certificate, oid asn1.ObjectIdentifier) bool {
+	for _, atv := range cert.Subject.Names {
+		if atv.Type.Equal(oid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findExtensionValue(cert *x509.Certificate, oid asn1.ObjectIdentifier) ([]byte, bool) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return ext.Value, true
+		}
+	}
+
+	return nil, false
+}
@@ -2,21 +2,59 @@ package ctlint
 
 import (
 	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/crtsh/ccadb_data"
 	"github.com/google/certificate-transparency-go/x509"
 )
 
+//go:generate go run ./cmd/precertsigningcas -out files/precert_signing_cas.tsv
+
+//go:embed files/precert_signing_cas.tsv
+var precertSigningCAsTSV string
+
 //go:embed files/precert_signing_ca_commonnames.txt
 var precertSigningCACNs string
 
+type precertSigningCA struct {
+	caOwner    string
+	commonName string
+}
+
+var precertSigningCABySPKISHA256 map[[sha256.Size]byte]*precertSigningCA
+var precertSigningCAByKeyID map[string]*precertSigningCA
 var precertSigningCACNMap map[string]struct{}
 
 func init() {
+	precertSigningCABySPKISHA256 = make(map[[sha256.Size]byte]*precertSigningCA)
+	precertSigningCAByKeyID = make(map[string]*precertSigningCA)
 	precertSigningCACNMap = make(map[string]struct{})
 
+	for line := range strings.SplitSeq(precertSigningCAsTSV, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		var spkiSHA256 [sha256.Size]byte
+		if n, err := hex.Decode(spkiSHA256[:], []byte(fields[0])); err != nil || n != sha256.Size {
+			continue
+		}
+		ca := &precertSigningCA{caOwner: fields[2], commonName: fields[3]}
+		precertSigningCABySPKISHA256[spkiSHA256] = ca
+		if keyID, err := hex.DecodeString(fields[1]); err == nil && len(keyID) > 0 {
+			precertSigningCAByKeyID[string(keyID)] = ca
+		}
+	}
+
 	for cn := range strings.SplitSeq(precertSigningCACNs, "\n") {
 		cn = strings.TrimSpace(cn)
 		if cn != "" {
@@ -25,7 +63,82 @@ func init() {
 	}
 }
 
-func CheckPrecertificate(precert *x509.Certificate) []string {
+// findPrecertSigningCA identifies the Precertificate Signing CA, if any, that issued precert.  The issuer's key is used to identify it, because Common Names collide and can be spoofed; the Issuer Common Name is only consulted if no key-based data is available.
+func findPrecertSigningCA(precert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte) *precertSigningCA {
+	if sha256IssuerSPKI != nil {
+		if ca, found := precertSigningCABySPKISHA256[*sha256IssuerSPKI]; found {
+			return ca
+		}
+	}
+
+	if len(precert.AuthorityKeyId) > 0 {
+		if ca, found := precertSigningCAByKeyID[string(precert.AuthorityKeyId)]; found {
+			return ca
+		} else if encoded, found := ccadb_data.GetIssuerSPKISHA256ByKeyIdentifier(base64.StdEncoding.EncodeToString(precert.AuthorityKeyId)); found {
+			if ca, found := precertSigningCABySPKISHA256[encoded]; found {
+				return ca
+			}
+		}
+	}
+
+	if len(precertSigningCABySPKISHA256) == 0 {
+		if _, found := precertSigningCACNMap[precert.Issuer.CommonName]; found {
+			return &precertSigningCA{commonName: precert.Issuer.CommonName}
+		}
+	}
+
+	return nil
+}
+
+func CheckPrecertificate(precert *x509.Certificate, policyGroup_optional ...CTPolicyGroup) []string {
+	return checkPrecertificate(precert, nil, policyGroup_optional)
+}
+
+// CheckPrecertificateWithIssuer is like CheckPrecertificate, except that it also checks that issuer actually signed precert, determines whether issuer is a Precertificate Signing Certificate or the CA itself, and reports the issuer_key_hash that logs should use for the precertificate entry.
+// If issuer is a Precertificate Signing Certificate, the CA certificate that issued it may also be provided as caCert; otherwise, caCert may be nil and that CA's key is sought in the available CCADB data.
+func CheckPrecertificateWithIssuer(precert *x509.Certificate, issuer *x509.Certificate, caCert *x509.Certificate, policyGroup_optional ...CTPolicyGroup) []string {
+	if precert == nil || issuer == nil {
+		return CheckPrecertificate(precert, policyGroup_optional...)
+	}
+
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	findings := checkPrecertificate(precert, &sha256IssuerSPKI, policyGroup_optional)
+
+	issuerFindings, isIssuer := checkIssuer(precert, issuer)
+	findings = append(findings, issuerFindings...)
+	if !isIssuer {
+		return append(findings, "E: Supplied issuer certificate did not sign this precertificate, so the issuer_key_hash cannot be determined")
+	}
+
+	// RFC6962 section 3.2: "issuer_key_hash is the SHA-256 hash of the certificate issuer's public key...".  The certificate issuer is the CA that will issue the final certificate, which is not the Precertificate Signing Certificate (if one is used).
+	var sha256CASPKI *[sha256.Size]byte
+	if !issuer.IsCA || !hasCTEKU(issuer) {
+		findings = append(findings, "I: Precertificate is signed directly by the CA")
+		sha256CASPKI = &sha256IssuerSPKI
+	} else {
+		findings = append(findings, "I: Precertificate is signed by a Precertificate Signing Certificate")
+		if caCert != nil {
+			findings = append(findings, CheckPrecertificateSigningCertificate(issuer, caCert)...)
+			encoded := sha256.Sum256(caCert.RawSubjectPublicKeyInfo)
+			sha256CASPKI = &encoded
+		} else {
+			var resolverFindings []string
+			sha256CASPKI, resolverFindings = CCADBIssuerResolver{}.ResolveIssuerSPKISHA256(issuer)
+			if sha256CASPKI == nil {
+				resolverFindings = []string{"W: Cannot determine the issuer_key_hash without the CA certificate that issued the Precertificate Signing Certificate, whose SPKI could not be found in the available CCADB data"}
+			}
+			findings = append(findings, resolverFindings...)
+		}
+	}
+
+	if sha256CASPKI != nil {
+		findings = append(findings, fmt.Sprintf("I: issuer_key_hash for this precertificate's log entries is %s", hex.EncodeToString(sha256CASPKI[:])))
+	}
+
+	return findings
+}
+
+func checkPrecertificate(precert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, policyGroup_optional []CTPolicyGroup) []string {
 	var findings []string
 
 	if precert == nil {
@@ -48,6 +161,8 @@ func CheckPrecertificate(precert *x509.Certificate) []string {
 				findings = append(findings, "E: SCT list extension is present")
 			} else if ext.Id.Equal(OIDExtensionOCSPCTSCT) {
 				findings = append(findings, "E: OCSP SCT list extension is present")
+			} else if ext.Id.Equal(OIDExtensionTransparencyInformation) {
+				findings = append(findings, "E: Transparency information extension is present")
 			}
 		}
 
@@ -57,11 +172,19 @@ func CheckPrecertificate(precert *x509.Certificate) []string {
 			findings = append([]string{"I: Precertificate identified"}, findings...)
 		}
 
-		if _, found := precertSigningCACNMap[precert.Issuer.CommonName]; found {
+		if policyGroup, _, _ := getPolicyGroup(precert, policyGroup_optional); policyGroup == MarkCertificate {
+			findings = append(findings, checkMarkCertificatePrecertificate(precert)...)
+		}
+
+		if ca := findPrecertSigningCA(precert, sha256IssuerSPKI); ca != nil {
+			description := "a Precertificate Signing CA"
+			if ca.caOwner != "" {
+				description += " belonging to " + ca.caOwner
+			}
 			if precert.NotBefore.Before(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)) {
-				findings = append(findings, "I: Precertificate issued by a Precertificate Signing CA")
+				findings = append(findings, fmt.Sprintf("I: Precertificate issued by %s", description))
 			} else {
-				findings = append(findings, "E: Precertificate issued by a Precertificate Signing CA after March 15, 2026")
+				findings = append(findings, fmt.Sprintf("E: Precertificate issued by %s after March 15, 2026", description))
 			}
 		}
 	}
@@ -0,0 +1,105 @@
+package ctlint
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	encasn1 "encoding/asn1"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// TestPrecertSigningCAs checks that files/precert_signing_cas.tsv has been generated, and that a Precertificate Signing CA in it is identified by its key rather than by its Common Name.
+func TestPrecertSigningCAs(t *testing.T) {
+	if len(precertSigningCABySPKISHA256) == 0 || len(precertSigningCAByKeyID) == 0 {
+		t.Fatal("files/precert_signing_cas.tsv contains no Precertificate Signing CAs; run \"go generate\"")
+	}
+
+	var keyID string
+	var want *precertSigningCA
+	for keyID, want = range precertSigningCAByKeyID {
+		if want.caOwner != "" {
+			break
+		}
+	}
+
+	// The precertificate's issuer is named differently, so only its Authority Key Identifier identifies the Precertificate Signing CA.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Not a Precertificate Signing CA"}, SubjectKeyId: []byte(keyID), NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(365 * 24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: stdx509.KeyUsageCertSign}
+	caDER, err := stdx509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := stdx509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &stdx509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.com"}, NotBefore: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), NotAfter: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), ExtraExtensions: []pkix.Extension{{Id: encasn1.ObjectIdentifier(x509.OIDExtensionCTPoison), Critical: true, Value: []byte{0x05, 0x00}}}}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	precert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := findPrecertSigningCA(precert, nil); got != want {
+		t.Fatalf("findPrecertSigningCA = %+v, want %+v", got, want)
+	}
+	if want.caOwner != "" {
+		if findings := strings.Join(CheckPrecertificate(precert), "\n"); !strings.Contains(findings, "I: Precertificate issued by a Precertificate Signing CA belonging to "+want.caOwner) {
+			t.Errorf("Precertificate Signing CA's owner is not reported:\n%s", findings)
+		}
+	}
+}
+
+func TestCheckPrecertificateWithIssuerPolicyGroup(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerTemplate := &stdx509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Test CA"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(365 * 24 * time.Hour), IsCA: true, BasicConstraintsValid: true, KeyUsage: stdx509.KeyUsageCertSign}
+	issuerDER, err := stdx509.CreateCertificate(rand.Reader, issuerTemplate, issuerTemplate, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdIssuer, err := stdx509.ParseCertificate(issuerDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := x509.ParseCertificate(issuerDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &stdx509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "example.com"}, DNSNames: []string{"example.com"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(90 * 24 * time.Hour), ExtraExtensions: []pkix.Extension{{Id: encasn1.ObjectIdentifier(x509.OIDExtensionCTPoison), Critical: true, Value: []byte{0x05, 0x00}}}}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, stdIssuer, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	precert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The specified policy group applies whether or not the issuer is supplied.
+	const markCertificateFinding = "E: Mark Certificate precertificate Subject does not include the organizationName attribute"
+	for _, findings := range [][]string{CheckPrecertificateWithIssuer(precert, issuer, nil, MarkCertificate), CheckPrecertificateWithIssuer(precert, nil, nil, MarkCertificate)} {
+		if !slices.Contains(findings, markCertificateFinding) {
+			t.Errorf("Mark Certificate policy group was not applied:\n%s", strings.Join(findings, "\n"))
+		}
+	}
+	if findings := CheckPrecertificateWithIssuer(precert, issuer, nil); slices.Contains(findings, markCertificateFinding) {
+		t.Errorf("Mark Certificate policy group was applied to a TLS precertificate:\n%s", strings.Join(findings, "\n"))
+	}
+}
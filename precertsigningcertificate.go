@@ -0,0 +1,106 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// CheckPrecertificateSigningCertificate checks a Precertificate Signing Certificate against the requirements of RFC6962 section 3.1, given the CA certificate that issued it.
+// If the final certificate corresponding to a precertificate that it signed is also provided, CheckPrecertificateSigningCertificate confirms that the final certificate was issued by the same CA and that the issuer_key_hash of each embedded SCT was derived from that CA's key.
+func CheckPrecertificateSigningCertificate(precertSigningCert *x509.Certificate, issuer *x509.Certificate, cert_optional ...*x509.Certificate) []string {
+	var findings []string
+
+	if precertSigningCert == nil {
+		return []string{"E: Precertificate Signing Certificate not provided"}
+	} else if issuer == nil {
+		return []string{"E: Issuer of Precertificate Signing Certificate not provided"}
+	}
+
+	// RFC6962 section 3.1: "...a special-purpose (CA:true, Extended Key Usage: Certificate Transparency, OID 1.3.6.1.4.1.11129.2.4.4) Precertificate Signing Certificate."
+	if !hasCTEKU(precertSigningCert) {
+		findings = append(findings, "E: Precertificate Signing Certificate does not contain the Certificate Transparency EKU")
+	} else if len(precertSigningCert.ExtKeyUsage) > 1 || len(precertSigningCert.UnknownExtKeyUsage) > 0 {
+		findings = append(findings, "N: Precertificate Signing Certificate contains EKUs other than the Certificate Transparency EKU")
+	}
+
+	if !precertSigningCert.BasicConstraintsValid || !precertSigningCert.IsCA {
+		findings = append(findings, "E: Precertificate Signing Certificate does not assert basicConstraints cA=TRUE")
+	} else if precertSigningCert.MaxPathLen != 0 || !precertSigningCert.MaxPathLenZero {
+		findings = append(findings, "N: Precertificate Signing Certificate does not limit basicConstraints pathLenConstraint to 0")
+	}
+
+	if precertSigningCert.KeyUsage != 0 && precertSigningCert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		findings = append(findings, "E: Precertificate Signing Certificate does not assert the keyCertSign key usage")
+	}
+
+	// RFC6962 section 3.1: "This Precertificate Signing Certificate MUST be directly certified by the CA certificate that will ultimately sign the end-entity TBSCertificate yielding the end-entity certificate".
+	if hasCTEKU(issuer) {
+		findings = append(findings, "E: Precertificate Signing Certificate is issued by another Precertificate Signing Certificate")
+	}
+	if _, isIssuer := checkIssuer(precertSigningCert, issuer); !isIssuer {
+		findings = append(findings, "E: Precertificate Signing Certificate is not directly issued by the specified CA certificate")
+	}
+
+	if len(cert_optional) > 0 && cert_optional[0] != nil {
+		findings = append(findings, checkFinalCertificateIssuerKeyHash(cert_optional[0], precertSigningCert, issuer)...)
+	}
+
+	if hasCTEKU(precertSigningCert) {
+		findings = append([]string{"I: Precertificate Signing Certificate identified"}, findings...)
+	}
+
+	return findings
+}
+
+func hasCTEKU(cert *x509.Certificate) bool {
+	for _, eku := range cert.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageCertificateTransparency {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkFinalCertificateIssuerKeyHash(cert *x509.Certificate, precertSigningCert *x509.Certificate, issuer *x509.Certificate) []string {
+	var findings []string
+
+	if _, isIssuer := checkIssuer(cert, issuer); !isIssuer {
+		findings = append(findings, "E: Final certificate is not issued by the CA that issued the Precertificate Signing Certificate")
+	}
+
+	tbsCert, err := x509.RemoveSCTList(cert.RawTBSCertificate)
+	if err != nil {
+		return append(findings, "E: Cannot remove SCT List extension to derive TBSCertificate")
+	}
+
+	// RFC6962 section 3.2: "issuer_key_hash is the SHA-256 hash of the certificate issuer's public key, calculated over the DER encoding of the key represented as SubjectPublicKeyInfo.  This is needed to bind the issuer to the final certificate."
+	// The final certificate's issuer is the CA, not the Precertificate Signing Certificate.
+	snapshot := CurrentLogListSnapshot()
+	sha256IssuerSPKI := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	sha256PrecertSigningCertSPKI := sha256.Sum256(precertSigningCert.RawSubjectPublicKeyInfo)
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(x509.OIDExtensionCTSCT) {
+			continue
+		}
+
+		scts, parseFindings := parseSCTListExtension(ext)
+		findings = append(findings, parseFindings...)
+		for _, sct := range scts {
+			sv := snapshot.signatureVerifiers[sct.LogID.KeyID]
+			if sv == nil {
+				continue
+			} else if sv.VerifySCTSignature(*sct, ctgo.LogEntry{Leaf: precertMerkleTreeLeaf(tbsCert, &sha256IssuerSPKI, sct)}) == nil {
+				continue
+			} else if sv.VerifySCTSignature(*sct, ctgo.LogEntry{Leaf: precertMerkleTreeLeaf(tbsCert, &sha256PrecertSigningCertSPKI, sct)}) == nil {
+				findings = append(findings, "E: SCT issuer_key_hash was derived from the Precertificate Signing Certificate's key instead of the CA's key")
+			} else {
+				findings = append(findings, "E: SCT signature cannot be verified using an issuer_key_hash derived from either the CA's key or the Precertificate Signing Certificate's key")
+			}
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,97 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"strings"
+
+	ctgo "github.com/google/certificate-transparency-go"
+)
+
+// resolvedLog is a log, as it appears in each of a snapshot's log lists.  Each log ID is resolved once, when the snapshot is built, so that checking an SCT doesn't repeatedly search every log list for the log that issued it.
+type resolvedLog struct {
+	description                                 string // For display purposes (see describeLog), or empty if the log is unknown.
+	chrome, apple, mozilla, bimi, crtsh, mimics *indexedLog
+}
+
+// unknownLog is the resolvedLog of a log ID that is not in any of a snapshot's log lists.
+var unknownLog = &resolvedLog{}
+
+// resolveLogs resolves every log ID that appears in any of the snapshot's log lists.
+func (snapshot *LogListSnapshot) resolveLogs() map[[sha256.Size]byte]*resolvedLog {
+	resolvedLogs := make(map[[sha256.Size]byte]*resolvedLog)
+	for _, index := range []*logListIndex{snapshot.chrome, snapshot.apple, snapshot.mozilla, snapshot.bimi, snapshot.crtsh, snapshot.mimics} {
+		for logID := range index.logs {
+			if _, found := resolvedLogs[logID]; found {
+				continue
+			}
+
+			log := &resolvedLog{
+				chrome:  snapshot.chrome.findLog(logID),
+				apple:   snapshot.apple.findLog(logID),
+				mozilla: snapshot.mozilla.findLog(logID),
+				bimi:    snapshot.bimi.findLog(logID),
+				crtsh:   snapshot.crtsh.findLog(logID),
+				mimics:  snapshot.mimics.findLog(logID),
+			}
+
+			// The crt.sh, gstatic, and mimic log lists should between them cover all known SCT signers.
+			for _, ctLog := range []*indexedLog{log.crtsh, log.chrome, log.mimics} {
+				if ctLog != nil {
+					log.description = "(" + ctLog.Description + ")"
+					if ctLog.operatorName != "" && !strings.Contains(log.description, ctLog.operatorName) {
+						log.description = ctLog.operatorName + " " + log.description
+					}
+					break
+				}
+			}
+
+			resolvedLogs[logID] = log
+		}
+	}
+
+	return resolvedLogs
+}
+
+// resolveLog returns the log with the specified log ID, as it appears in each of the snapshot's log lists.
+func (snapshot *LogListSnapshot) resolveLog(logID [sha256.Size]byte) *resolvedLog {
+	if log, found := snapshot.resolvedLogs[logID]; found {
+		return log
+	}
+	return unknownLog
+}
+
+// resolveSCTLogs returns the log that issued each SCT.
+func (snapshot *LogListSnapshot) resolveSCTLogs(scts []*ctgo.SignedCertificateTimestamp) []*resolvedLog {
+	logs := make([]*resolvedLog, len(scts))
+	for i, sct := range scts {
+		logs[i] = snapshot.resolveLog(sct.LogID.KeyID)
+	}
+	return logs
+}
+
+// inLogList returns the log as it appears in the log list with the specified name (as returned by LogLists), or nil if that log list does not contain it.
+func (log *resolvedLog) inLogList(name string) *indexedLog {
+	switch name {
+	case "Chrome":
+		return log.chrome
+	case "Apple":
+		return log.apple
+	case "Mozilla":
+		return log.mozilla
+	case "BIMI":
+		return log.bimi
+	case "crt.sh":
+		return log.crtsh
+	default:
+		return nil
+	}
+}
+
+// inLogList returns the log that issued each SCT, as it appears in the log list with the specified name.
+func inLogList(logs []*resolvedLog, name string) []*indexedLog {
+	ctLogs := make([]*indexedLog, len(logs))
+	for i, log := range logs {
+		ctLogs[i] = log.inLogList(name)
+	}
+	return ctLogs
+}
@@ -0,0 +1,48 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"strings"
+)
+
+//go:generate go run ./cmd/issuerrootprograms -out files/issuer_root_programs.tsv
+
+//go:embed files/issuer_root_programs.tsv
+var issuerRootProgramsTSV string
+
+var issuerRootProgramsMap map[[sha256.Size]byte][]string
+
+func init() {
+	issuerRootProgramsMap = make(map[[sha256.Size]byte][]string)
+
+	for line := range strings.SplitSeq(issuerRootProgramsTSV, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 2 {
+			continue
+		}
+		var spkiSHA256 [sha256.Size]byte
+		if n, err := hex.Decode(spkiSHA256[:], []byte(fields[0])); err != nil || n != sha256.Size {
+			continue
+		}
+		var rootPrograms []string
+		if fields[1] != "" {
+			rootPrograms = strings.Split(fields[1], ",")
+		}
+		issuerRootProgramsMap[spkiSHA256] = rootPrograms
+	}
+}
+
+// getIssuerRootPrograms returns the root programs (e.g., "Chrome") that trust the hierarchy of the CA whose SPKI has the specified hash, and whether that CA is known.
+func getIssuerRootPrograms(sha256IssuerSPKI *[sha256.Size]byte) ([]string, bool) {
+	if sha256IssuerSPKI == nil {
+		return nil, false
+	}
+
+	rootPrograms, found := issuerRootProgramsMap[*sha256IssuerSPKI]
+	return rootPrograms, found
+}
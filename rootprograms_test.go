@@ -0,0 +1,40 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestIssuerRootPrograms checks that files/issuer_root_programs.tsv has been generated, and that only the CT Policies of the root programs that trust an issuing CA's hierarchy are evaluated.
+func TestIssuerRootPrograms(t *testing.T) {
+	if len(issuerRootProgramsMap) == 0 {
+		t.Fatal("files/issuer_root_programs.tsv contains no issuing CAs; run \"go generate\"")
+	}
+
+	var sha256IssuerSPKI *[sha256.Size]byte
+	var rootProgram string
+	for spkiSHA256, rootPrograms := range issuerRootProgramsMap {
+		if len(rootPrograms) == 1 && slices.Contains([]string{"Chrome", "Apple", "Mozilla"}, rootPrograms[0]) {
+			sha256IssuerSPKI, rootProgram = &spkiSHA256, rootPrograms[0]
+			break
+		}
+	}
+	if sha256IssuerSPKI == nil {
+		t.Fatal("files/issuer_root_programs.tsv contains no issuing CA that is trusted by only one root program")
+	}
+
+	logKeys := loadTestLogList(t, "Operator A", "Operator B")
+	cert, _ := newTestCertificateWithValidSCTs(t, logKeys)
+	findings := strings.Join(CheckCertificate(cert, sha256IssuerSPKI), "\n")
+	for _, ctPolicyName := range []string{"Chrome", "Apple", "Mozilla"} {
+		notApplicable := "I: " + ctPolicyName + " CT Policy does not apply, because the issuing hierarchy is not trusted by " + ctPolicyName
+		if isApplicable := ctPolicyName == rootProgram; isApplicable == strings.Contains(findings, notApplicable) {
+			t.Errorf("%s CT Policy applicability is wrong for a hierarchy that only %s trusts:\n%s", ctPolicyName, rootProgram, findings)
+		}
+	}
+	if strings.Contains(findings, "CCADB data, so CT Policy findings are informational only") {
+		t.Errorf("CT Policy findings are informational for a known issuing CA:\n%s", findings)
+	}
+}
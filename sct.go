@@ -3,14 +3,12 @@ package ctlint
 import (
 	"crypto/sha256"
 	"fmt"
-	"strings"
 	"time"
 
-	"github.com/crtsh/ctloglists"
 	ctgo "github.com/google/certificate-transparency-go"
 )
 
-func verifySCT(tbsCert []byte, sha256IssuerSPKI *[sha256.Size]byte, sct *ctgo.SignedCertificateTimestamp) []string {
+func verifySCT(tbsCert []byte, sha256IssuerSPKI *[sha256.Size]byte, sct *ctgo.SignedCertificateTimestamp, log *resolvedLog, snapshot *LogListSnapshot) []string {
 	if sct.SCTVersion != ctgo.V1 {
 		return []string{"E: SCT version is not V1"}
 	}
@@ -20,55 +18,44 @@ func verifySCT(tbsCert []byte, sha256IssuerSPKI *[sha256.Size]byte, sct *ctgo.Si
 		findings = append(findings, "E: SCT timestamp is in the future")
 	}
 
-	merkleTreeLeaf := ctgo.MerkleTreeLeaf{
-		Version:  ctgo.V1,
-		LeafType: ctgo.TimestampedEntryLeafType,
-		TimestampedEntry: &ctgo.TimestampedEntry{
-			EntryType: ctgo.PrecertLogEntryType,
-			Timestamp: sct.Timestamp,
-			PrecertEntry: &ctgo.PreCert{
-				IssuerKeyHash:  *sha256IssuerSPKI,
-				TBSCertificate: tbsCert,
-			},
-			Extensions: sct.Extensions,
-		},
-	}
+	merkleTreeLeaf := precertMerkleTreeLeaf(tbsCert, sha256IssuerSPKI, sct)
 
-	sv := ctloglists.LogSignatureVerifierMap[([sha256.Size]byte)(sct.LogID.KeyID)]
+	sv := snapshot.signatureVerifiers[sct.LogID.KeyID]
 	if sv == nil {
 		return append(findings, "N: SCT is from an unknown log")
 	}
 
-	// Get the log description, for display purposes.  The crt.sh, gstatic, and mimic log lists should between them cover all known SCT signers.
-	log, operator, _ := findLogByKeyHash(sct.LogID.KeyID, ctloglists.CrtshV3All)
-	if log == nil {
-		log, operator, _ = findLogByKeyHash(sct.LogID.KeyID, ctloglists.GstaticV3All)
-	}
-	if log == nil {
-		log, operator, _ = findLogByKeyHash(sct.LogID.KeyID, ctloglists.LogMimics)
-	}
-
-	// Ensure that the Operator name is prepended to the log description, if not already present, for display purposes.
-	description := ""
-	if log != nil {
-		description = "(" + log.Description + ")"
-		if operator != "" && !strings.Contains(description, operator) {
-			description = operator + " " + description
-		}
-	}
+	// Get the log description, for display purposes.
+	description, isLogKnown := log.description, log.description != ""
 
 	err := sv.VerifySCTSignature(*sct, ctgo.LogEntry{Leaf: merkleTreeLeaf})
 	if err != nil {
-		if log != nil {
+		if isLogKnown {
 			return append(findings, fmt.Sprintf("E: SCT has an invalid signature purporting to be from %s", description))
 		} else {
 			return append(findings, "E: SCT has an invalid signature")
 		}
 	}
 
-	if log != nil {
+	if isLogKnown {
 		return append(findings, fmt.Sprintf("I: SCT has a valid signature from %s", description))
 	} else {
 		return append(findings, "I: SCT has a valid signature")
 	}
 }
+
+func precertMerkleTreeLeaf(tbsCert []byte, sha256IssuerSPKI *[sha256.Size]byte, sct *ctgo.SignedCertificateTimestamp) ctgo.MerkleTreeLeaf {
+	return ctgo.MerkleTreeLeaf{
+		Version:  ctgo.V1,
+		LeafType: ctgo.TimestampedEntryLeafType,
+		TimestampedEntry: &ctgo.TimestampedEntry{
+			EntryType: ctgo.PrecertLogEntryType,
+			Timestamp: sct.Timestamp,
+			PrecertEntry: &ctgo.PreCert{
+				IssuerKeyHash:  *sha256IssuerSPKI,
+				TBSCertificate: tbsCert,
+			},
+			Extensions: sct.Extensions,
+		},
+	}
+}
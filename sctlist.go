@@ -11,25 +11,69 @@ import (
 	"github.com/google/certificate-transparency-go/x509util"
 )
 
-func checkSCTListExtension(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup, sha256IssuerSPKI *[sha256.Size]byte, sctListExt pkix.Extension) []string {
-	var findings []string
+func checkSCTListExtension(cert *x509.Certificate, ctPolicyGroup CTPolicyGroup, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, sctListExt pkix.Extension) []string {
+	scts, findings := parseSCTListExtension(sctListExt)
+	if len(findings) > 0 {
+		return findings
+	}
+
+	return checkSCTListCompliance(cert, ctPolicyGroup, sha256IssuerSPKI, issuerResolver, scts)
+}
+
+// CheckSCTList checks a TLS-encoded SignedCertificateTimestampList that was obtained for precert (e.g., before the SCTs are embedded in the final certificate), as if the SCTs were embedded in the corresponding final certificate.
+// precert must have been signed by the CA that will issue the final certificate, whose SPKI hash may be provided as sha256IssuerSPKI; otherwise, it is sought in the available CCADB data.
+func CheckSCTList(precert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, sctList []byte, policyGroup_optional ...CTPolicyGroup) []string {
+	return checkSCTList(precert, sha256IssuerSPKI, CCADBIssuerResolver{}, sctList, policyGroup_optional)
+}
+
+// CheckSCTListWithIssuerResolver is like CheckSCTList, except that the issuer SPKI is determined by the specified IssuerResolver.
+func CheckSCTListWithIssuerResolver(precert *x509.Certificate, issuerResolver IssuerResolver, sctList []byte, policyGroup_optional ...CTPolicyGroup) []string {
+	return checkSCTList(precert, nil, issuerResolver, sctList, policyGroup_optional)
+}
+
+func checkSCTList(precert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, sctList []byte, policyGroup_optional []CTPolicyGroup) []string {
+	if precert == nil {
+		return []string{"E: Precertificate not provided"}
+	}
+
+	// The SCTs are verified over the final certificate's TBSCertificate without its SCT list extension, which is the precertificate's TBSCertificate without its poison extension.
+	tbsCert, err := x509.RemoveCTPoison(precert.RawTBSCertificate)
+	if err != nil {
+		return []string{"E: Cannot remove Precertificate 'poison' extension to derive TBSCertificate"}
+	}
+
+	scts, findings := parseSCTList(sctList)
+	if len(findings) > 0 {
+		return findings
+	}
+
+	policyGroup, _, _ := getPolicyGroup(precert, policyGroup_optional)
+	return checkSCTListComplianceWithTBSCertificate(precert, tbsCert, policyGroup, sha256IssuerSPKI, issuerResolver, scts)
+}
 
+func parseSCTListExtension(sctListExt pkix.Extension) ([]*ctgo.SignedCertificateTimestamp, []string) {
 	var sctListExtValue []byte
-	var sctList x509.SignedCertificateTimestampList
-	var scts []*ctgo.SignedCertificateTimestamp
 	if rest, err := asn1.Unmarshal(sctListExt.Value, &sctListExtValue); err != nil {
-		findings = append(findings, "E: SCT list extension could not be parsed")
+		return nil, []string{"E: SCT list extension could not be parsed"}
 	} else if len(rest) != 0 {
-		findings = append(findings, "E: SCT list extension contains trailing data")
-	} else if rest, err := tls.Unmarshal(sctListExtValue, &sctList); err != nil {
+		return nil, []string{"E: SCT list extension contains trailing data"}
+	}
+
+	return parseSCTList(sctListExtValue)
+}
+
+func parseSCTList(sctListValue []byte) ([]*ctgo.SignedCertificateTimestamp, []string) {
+	var findings []string
+
+	var sctList x509.SignedCertificateTimestampList
+	var scts []*ctgo.SignedCertificateTimestamp
+	if rest, err := tls.Unmarshal(sctListValue, &sctList); err != nil {
 		findings = append(findings, "E: SCT list could not be parsed")
 	} else if len(rest) != 0 {
 		findings = append(findings, "E: SCT list contains trailing data")
 	} else if scts, err = x509util.ParseSCTsFromSCTList(&sctList); err != nil {
 		findings = append(findings, "E: SCTs could not be parsed from SCT list")
-	} else {
-		findings = append(findings, checkSCTListCompliance(cert, ctPolicyGroup, sha256IssuerSPKI, scts)...)
 	}
 
-	return findings
+	return scts, findings
 }
@@ -0,0 +1,236 @@
+package ctlint
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/certificate-transparency-go/asn1"
+	"github.com/google/certificate-transparency-go/x509"
+	"github.com/google/certificate-transparency-go/x509/pkix"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// RFC9162 section 7.1.2: "The Transparency Information X.509v3 extension, which has OID 1.3.101.75 and SHOULD be non-critical, contains one or more TransItem structures in a TransItemList."
+var OIDExtensionTransparencyInformation = asn1.ObjectIdentifier{1, 3, 101, 75}
+
+// RFC9162 section 4.5: VersionedTransType.
+const (
+	x509EntryV2        uint16 = 1
+	precertEntryV2     uint16 = 2
+	x509SCTV2          uint16 = 3
+	precertSCTV2       uint16 = 4
+	signedTreeHeadV2   uint16 = 5
+	consistencyProofV2 uint16 = 6
+	inclusionProofV2   uint16 = 7
+)
+
+// V2Log describes a RFC9162 log.  Unlike RFC6962 logs, which are identified by the hash of their public key, RFC9162 logs are identified by an OID.
+type V2Log struct {
+	LogID       asn1.ObjectIdentifier
+	PublicKey   crypto.PublicKey
+	Description string
+}
+
+var v2LogMap = make(map[string]*V2Log)
+var v2LogMapMutex sync.RWMutex
+
+// RegisterV2Log makes a RFC9162 log known to ctlint, so that the signatures on SCTs from that log can be verified.
+// RFC9162 section 2.1.4 permits logs to use ECDSA with the NIST P-256 curve, or Ed25519.
+func RegisterV2Log(log *V2Log) error {
+	switch publicKey := log.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if publicKey.Curve != elliptic.P256() {
+			return errors.New("RFC9162 logs must use the NIST P-256 curve")
+		}
+	case ed25519.PublicKey:
+	default:
+		return errors.New("RFC9162 logs must use ECDSA or Ed25519 keys")
+	}
+
+	logID, err := marshalV2LogID(log.LogID)
+	if err != nil {
+		return err
+	}
+
+	v2LogMapMutex.Lock()
+	defer v2LogMapMutex.Unlock()
+	v2LogMap[string(logID)] = log
+	return nil
+}
+
+// marshalV2LogID returns the LogID of a RFC9162 log.  RFC9162 section 4.4: "...the contents of the DER encoding of an OID, excluding the ASN.1 tag and length bytes".
+func marshalV2LogID(oid asn1.ObjectIdentifier) ([]byte, error) {
+	der, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw asn1.RawValue
+	if _, err = asn1.Unmarshal(der, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw.Bytes, nil
+}
+
+func formatV2LogID(logID []byte) string {
+	var oid asn1.ObjectIdentifier
+	if der, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagOID, Bytes: logID}); err != nil {
+		return fmt.Sprintf("%X", logID)
+	} else if _, err = asn1.Unmarshal(der, &oid); err != nil {
+		return fmt.Sprintf("%X", logID)
+	}
+
+	return oid.String()
+}
+
+// transItem holds the fields of a RFC9162 TransItem that ctlint needs.  Only SCT TransItems have their data parsed.
+type transItem struct {
+	versionedType uint16
+	logID         []byte
+	timestamp     uint64
+	sctExtensions []byte
+	signature     []byte
+}
+
+// RFC9162 section 4.5: "TransItem trans_item_list<1..2^16-1>;"
+func parseTransItemList(data []byte) ([]*transItem, error) {
+	input := cryptobyte.String(data)
+	var list cryptobyte.String
+	if !input.ReadUint16LengthPrefixed(&list) || !input.Empty() {
+		return nil, errors.New("malformed TransItemList")
+	} else if list.Empty() {
+		return nil, errors.New("empty TransItemList")
+	}
+
+	var items []*transItem
+	for !list.Empty() {
+		item := &transItem{}
+		if !list.ReadUint16(&item.versionedType) {
+			return nil, errors.New("malformed TransItem")
+		}
+
+		var ok bool
+		switch item.versionedType {
+		case x509SCTV2, precertSCTV2:
+			// RFC9162 section 4.8: SignedCertificateTimestampDataV2.
+			var logID, sctExtensions, signature cryptobyte.String
+			ok = list.ReadUint8LengthPrefixed(&logID) && list.ReadUint64(&item.timestamp) && list.ReadUint16LengthPrefixed(&sctExtensions) && list.ReadUint16LengthPrefixed(&signature)
+			item.logID, item.sctExtensions, item.signature = logID, sctExtensions, signature
+		case x509EntryV2, precertEntryV2:
+			// RFC9162 section 4.7: TimestampedCertificateEntryDataV2.
+			var issuerKeyHash, tbsCertificate, sctExtensions cryptobyte.String
+			ok = list.ReadUint64(&item.timestamp) && list.ReadUint8LengthPrefixed(&issuerKeyHash) && list.ReadUint24LengthPrefixed(&tbsCertificate) && list.ReadUint16LengthPrefixed(&sctExtensions)
+		case signedTreeHeadV2:
+			// RFC9162 section 4.10: SignedTreeHeadDataV2.
+			var logID, rootHash, sthExtensions, signature cryptobyte.String
+			var timestamp, treeSize uint64
+			ok = list.ReadUint8LengthPrefixed(&logID) && list.ReadUint64(&timestamp) && list.ReadUint64(&treeSize) && list.ReadUint8LengthPrefixed(&rootHash) && list.ReadUint16LengthPrefixed(&sthExtensions) && list.ReadUint16LengthPrefixed(&signature)
+			item.logID = logID
+		case consistencyProofV2, inclusionProofV2:
+			// RFC9162 sections 4.11 and 4.12: ConsistencyProofDataV2 and InclusionProofDataV2.
+			var logID, path cryptobyte.String
+			var first, second uint64
+			ok = list.ReadUint8LengthPrefixed(&logID) && list.ReadUint64(&first) && list.ReadUint64(&second) && list.ReadUint16LengthPrefixed(&path)
+			item.logID = logID
+		}
+		if !ok {
+			return nil, fmt.Errorf("malformed or unsupported TransItem (type %d)", item.versionedType)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func checkTransparencyInformationExtension(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte, issuerResolver IssuerResolver, ext pkix.Extension) []string {
+	var transItemListBytes []byte
+	if rest, err := asn1.Unmarshal(ext.Value, &transItemListBytes); err != nil {
+		return []string{"E: Transparency information extension could not be parsed"}
+	} else if len(rest) != 0 {
+		return []string{"E: Transparency information extension contains trailing data"}
+	}
+
+	items, err := parseTransItemList(transItemListBytes)
+	if err != nil {
+		return []string{"E: Transparency information TransItemList could not be parsed"}
+	}
+
+	// The SCTs were issued for the precertificate's TBSCertificate, which lacks the transparency information extension.
+	tbsCert, err := removeExtension(cert.RawTBSCertificate, OIDExtensionTransparencyInformation)
+	if err != nil {
+		return []string{"E: Cannot remove transparency information extension to derive TBSCertificate"}
+	}
+
+	var findings []string
+	for _, item := range items {
+		switch item.versionedType {
+		case precertSCTV2:
+			if sha256IssuerSPKI == nil {
+				var resolverFindings []string
+				if sha256IssuerSPKI, resolverFindings = issuerResolver.ResolveIssuerSPKISHA256(cert); sha256IssuerSPKI == nil {
+					return append(findings, resolverFindings...)
+				}
+				findings = append(findings, resolverFindings...)
+			}
+			findings = append(findings, verifySCTV2(tbsCert, sha256IssuerSPKI, item)...)
+		case x509SCTV2:
+			findings = append(findings, "E: Transparency information extension contains an x509_sct_v2 SCT, which cannot be embedded in the certificate it covers")
+		case inclusionProofV2, signedTreeHeadV2:
+			findings = append(findings, fmt.Sprintf("I: Transparency information extension contains an unchecked TransItem (type %d)", item.versionedType))
+		default:
+			findings = append(findings, fmt.Sprintf("E: Transparency information extension contains a TransItem of a type (%d) that is not permitted in certificates", item.versionedType))
+		}
+	}
+
+	return findings
+}
+
+// verifySCTV2 verifies a RFC9162 precert_sct_v2 SCT.  RFC9162 section 4.8: "signature: ...over a TransItem structure of type x509_entry_v2 or precert_entry_v2 (see Section 4.7) that contains the TimestampedCertificateEntryDataV2 structure".
+func verifySCTV2(tbsCert []byte, sha256IssuerSPKI *[sha256.Size]byte, item *transItem) []string {
+	var findings []string
+	if time.UnixMilli(int64(item.timestamp)).After(time.Now().Add(time.Second)) {
+		findings = append(findings, "E: RFC9162 SCT timestamp is in the future")
+	}
+
+	v2LogMapMutex.RLock()
+	log := v2LogMap[string(item.logID)]
+	v2LogMapMutex.RUnlock()
+	if log == nil {
+		return append(findings, fmt.Sprintf("N: RFC9162 SCT is from an unknown log (%s)", formatV2LogID(item.logID)))
+	}
+
+	var builder cryptobyte.Builder
+	builder.AddUint16(precertEntryV2)
+	builder.AddUint64(item.timestamp)
+	builder.AddUint8LengthPrefixed(func(child *cryptobyte.Builder) { child.AddBytes(sha256IssuerSPKI[:]) })
+	builder.AddUint24LengthPrefixed(func(child *cryptobyte.Builder) { child.AddBytes(tbsCert) })
+	builder.AddUint16LengthPrefixed(func(child *cryptobyte.Builder) { child.AddBytes(item.sctExtensions) })
+	signed, err := builder.Bytes()
+	if err != nil {
+		return append(findings, "E: RFC9162 SCT signature input could not be constructed")
+	}
+
+	var valid bool
+	switch publicKey := log.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(signed)
+		valid = ecdsa.VerifyASN1(publicKey, digest[:], item.signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(publicKey, signed, item.signature)
+	}
+
+	if valid {
+		return append(findings, fmt.Sprintf("I: RFC9162 SCT has a valid signature from %s (%s)", log.Description, log.LogID))
+	} else {
+		return append(findings, fmt.Sprintf("E: RFC9162 SCT has an invalid signature purporting to be from %s (%s)", log.Description, log.LogID))
+	}
+}
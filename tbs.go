@@ -0,0 +1,141 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	encodingasn1 "encoding/asn1"
+	"errors"
+
+	"github.com/google/certificate-transparency-go/asn1"
+	"github.com/google/certificate-transparency-go/x509"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// CheckTBSPrecertificate is like CheckPrecertificate, except that it accepts a DER-encoded TBSCertificate that has not yet been signed, so that the precertificate can be checked before the signing operation.
+func CheckTBSPrecertificate(tbs []byte, policyGroup_optional ...CTPolicyGroup) []string {
+	precert, err := ParseTBSCertificate(tbs)
+	if err != nil {
+		return []string{"E: TBSCertificate could not be parsed"}
+	}
+
+	return CheckPrecertificate(precert, policyGroup_optional...)
+}
+
+// CheckTBSCertificate is like CheckCertificate, except that it accepts a DER-encoded TBSCertificate that has not yet been signed, so that the certificate can be checked before the signing operation.
+func CheckTBSCertificate(tbs []byte, sha256IssuerSPKI *[sha256.Size]byte, policyGroup_optional ...CTPolicyGroup) []string {
+	cert, err := ParseTBSCertificate(tbs)
+	if err != nil {
+		return []string{"E: TBSCertificate could not be parsed"}
+	}
+
+	return CheckCertificate(cert, sha256IssuerSPKI, policyGroup_optional...)
+}
+
+// CheckTBSCertificateWithIssuerResolver is like CheckTBSCertificate, except that the issuer SPKI is determined by the specified IssuerResolver.
+func CheckTBSCertificateWithIssuerResolver(tbs []byte, issuerResolver IssuerResolver, policyGroup_optional ...CTPolicyGroup) []string {
+	cert, err := ParseTBSCertificate(tbs)
+	if err != nil {
+		return []string{"E: TBSCertificate could not be parsed"}
+	}
+
+	return CheckCertificateWithIssuerResolver(cert, issuerResolver, policyGroup_optional...)
+}
+
+// ParseTBSCertificate parses a DER-encoded TBSCertificate that has not been signed (e.g., before the HSM signing operation), by wrapping it in a Certificate with an empty signature.
+func ParseTBSCertificate(tbs []byte) (*x509.Certificate, error) {
+	signatureAlgorithm := tbsSignatureAlgorithm(tbs)
+	if signatureAlgorithm == nil {
+		return nil, errors.New("malformed TBSCertificate")
+	}
+
+	var builder cryptobyte.Builder
+	builder.AddASN1(cbasn1.SEQUENCE, func(child *cryptobyte.Builder) {
+		child.AddBytes(tbs)
+		child.AddBytes(signatureAlgorithm)
+		child.AddASN1BitString(nil)
+	})
+	certDER, err := builder.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(certDER)
+}
+
+// tbsSignatureAlgorithm returns the DER-encoded AlgorithmIdentifier in the signature field of a DER-encoded TBSCertificate, or nil if the TBSCertificate is malformed.
+func tbsSignatureAlgorithm(tbs []byte) []byte {
+	input := cryptobyte.String(tbs)
+	var tbsContents, signatureAlgorithm cryptobyte.String
+	if !input.ReadASN1(&tbsContents, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil
+	} else if !tbsContents.SkipOptionalASN1(cbasn1.Tag(0).Constructed().ContextSpecific()) || !tbsContents.SkipASN1(cbasn1.INTEGER) || !tbsContents.ReadASN1Element(&signatureAlgorithm, cbasn1.SEQUENCE) {
+		return nil
+	}
+
+	return signatureAlgorithm
+}
+
+// removeExtension removes every instance of the specified extension from a DER-encoded TBSCertificate.  The extensions field is omitted entirely if no other extensions remain.
+func removeExtension(tbs []byte, oid asn1.ObjectIdentifier) ([]byte, error) {
+	input := cryptobyte.String(tbs)
+	var tbsContents cryptobyte.String
+	if !input.ReadASN1(&tbsContents, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errors.New("malformed TBSCertificate")
+	}
+
+	extensionsTag := cbasn1.Tag(3).Constructed().ContextSpecific()
+	var builder cryptobyte.Builder
+	var parseErr error
+	builder.AddASN1(cbasn1.SEQUENCE, func(child *cryptobyte.Builder) {
+		for !tbsContents.Empty() {
+			var element cryptobyte.String
+			var tag cbasn1.Tag
+			if !tbsContents.ReadAnyASN1Element(&element, &tag) {
+				parseErr = errors.New("malformed TBSCertificate field")
+				return
+			} else if tag != extensionsTag {
+				child.AddBytes(element)
+				continue
+			}
+
+			var extensionsWrapper, extensions cryptobyte.String
+			if !element.ReadASN1(&extensionsWrapper, extensionsTag) || !extensionsWrapper.ReadASN1(&extensions, cbasn1.SEQUENCE) {
+				parseErr = errors.New("malformed TBSCertificate extensions")
+				return
+			}
+
+			var keptExtensions [][]byte
+			for !extensions.Empty() {
+				var extension, extensionContents cryptobyte.String
+				var extnID encodingasn1.ObjectIdentifier
+				if !extensions.ReadASN1Element(&extension, cbasn1.SEQUENCE) {
+					parseErr = errors.New("malformed extension")
+					return
+				}
+				extensionContents = extension
+				if !extensionContents.ReadASN1(&extensionContents, cbasn1.SEQUENCE) || !extensionContents.ReadASN1ObjectIdentifier(&extnID) {
+					parseErr = errors.New("malformed extension")
+					return
+				}
+				if !asn1.ObjectIdentifier(extnID).Equal(oid) {
+					keptExtensions = append(keptExtensions, extension)
+				}
+			}
+
+			if len(keptExtensions) > 0 {
+				child.AddASN1(extensionsTag, func(wrapper *cryptobyte.Builder) {
+					wrapper.AddASN1(cbasn1.SEQUENCE, func(sequence *cryptobyte.Builder) {
+						for _, extension := range keptExtensions {
+							sequence.AddBytes(extension)
+						}
+					})
+				})
+			}
+		}
+	})
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	return builder.Bytes()
+}
@@ -0,0 +1,86 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+/* This file was automatically generated by getCTKnownLogs.py. */
+
+#ifndef CTKnownLogs_h
+#define CTKnownLogs_h
+
+#include "CTLog.h"
+
+#include <stddef.h>
+
+static const PRTime kCTExpirationTime = INT64_C(1767225600000000);
+
+namespace mozilla::ct {
+
+enum class CTLogState {
+  Admissible,  // Qualified, Usable, or ReadOnly
+  Retired,     // Retired or Rejected
+};
+
+enum class CTLogFormat {
+  RFC6962,
+  Tiled,
+};
+
+struct CTLogInfo {
+  // See bug 1338873 about making these fields const.
+  const char* name;
+  CTLogState state;
+  CTLogFormat format;
+  uint64_t timestamp;
+  // Index within kCTLogOperatorList.
+  mozilla::ct::CTLogOperatorId operatorIndex;
+  const char* key;
+  size_t keyLength;
+};
+
+struct CTLogOperatorInfo {
+  // See bug 1338873 about making these fields const.
+  const char* name;
+  mozilla::ct::CTLogOperatorId id;
+};
+
+const CTLogInfo kCTLogList[] = {
+    {"Example 'Argon2026h1' log", CTLogState::Admissible, CTLogFormat::RFC6962,
+     0,  // no timestamp
+     0,  // operated by Example
+     "\x30\x59\x30\x13\x06\x07\x2a\x86\x48\xce\x3d\x02\x01\x06\x08\x2a"
+     "\x86\x48\xce\x3d\x03\x01\x07\x03\x42\x00\x04\x7e\x27\xa0\xf6\x3d"
+     "\x5b\x17\x57\x73\x04\x0f\x1c\xb3\x10\x24\xa9\x82\x55\x72\xd3\xd3"
+     "\x29\x7c\xc7\xec\x89\xa3\x35\xce\xc5\xb4\xbe\xa7\xa6\xe5\x63\x2b"
+     "\xde\xcd\x43\xbc\xdc\x34\x59\xf0\x32\xcf\x4b\x19\x0f\x38\x19\xe4"
+     "\x26\x4c\xc2\xcb\x79\x01\x85\x4c\x34\x06\x89",
+     91},
+    {"Example 'Xenon2020' log", CTLogState::Retired, CTLogFormat::RFC6962,
+     1588550400000,  // 2020-05-04T00:00:00Z
+     0,  // operated by Example
+     "\x30\x59\x30\x13\x06\x07\x2a\x86\x48\xce\x3d\x02\x01\x06\x08\x2a"
+     "\x86\x48\xce\x3d\x03\x01\x07\x03\x42\x00\x04\xd9\x77\x13\x27\x03"
+     "\xef\x12\x25\x2d\x0f\x9b\x2e\x92\x17\x95\xaf\x44\xda\x8d\xa8\xe8"
+     "\x62\xc0\x8a\x6b\x39\xf9\xb2\x49\x9a\x5a\xb2\xdf\x34\x44\x05\x8e"
+     "\x8a\xe0\xbf\x39\x66\xcb\x12\x0f\x11\xf6\x26\x88\x37\xee\x6e\xa7"
+     "\x06\x16\x42\x1d\xeb\x04\x97\xf6\xdf\x79\x08",
+     91},
+    {"Example \"Tiles\" 2026h1", CTLogState::Admissible, CTLogFormat::Tiled,
+     0,  // no timestamp
+     1,  // operated by Other Operator
+     "\x30\x59\x30\x13\x06\x07\x2a\x86\x48\xce\x3d\x02\x01\x06\x08\x2a"
+     "\x86\x48\xce\x3d\x03\x01\x07\x03\x42\x00\x04\x8e\x93\x0c\x18\x17"
+     "\x2b\x75\x61\xc0\x06\x06\x06\xae\xa4\x18\xfd\x39\xcd\xd4\x10\x7e"
+     "\x37\x28\xf2\x1b\x6f\x2b\x25\x69\xa1\x70\xcf\x77\xfa\x8d\xa9\x33"
+     "\x8f\x4b\x3c\x40\xa8\xa1\x0a\xf3\x4e\x33\x5e\x36\x26\xca\xff\xe4"
+     "\x41\xb8\x0d\xc3\x16\x72\x96\xb7\x53\x36\x1a",
+     91},
+};
+
+const CTLogOperatorInfo kCTLogOperatorList[] = {
+    {"Example", 0},
+    {"Other Operator", 1},
+};
+
+}  // namespace mozilla::ct
+
+#endif  // CTKnownLogs_h
@@ -0,0 +1,66 @@
+/* This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at http://mozilla.org/MPL/2.0/. */
+
+/* This file was automatically generated by getCTKnownLogs.py. */
+
+#ifndef CTKnownLogs_h
+#define CTKnownLogs_h
+
+#include "CTLog.h"
+
+#include <stddef.h>
+
+static const PRTime kCTExpirationTime = INT64_C(1767225600000000);
+
+enum class CTLogStatus {
+  Included,      // Qualified, Usable, or ReadOnly
+  Disqualified,  // Retired or Rejected
+};
+
+struct CTLogInfo {
+  // See bug 1338873 about making these fields const.
+  const char* name;
+  // Status indicates whether the log is currently included, or if it was
+  // disqualified (and the time at which it was disqualified).
+  CTLogStatus status;
+  uint64_t disqualificationTime;  // in milliseconds since the epoch
+  size_t operatorIndex;           // index into kCTLogOperatorList
+  const char* key;
+  size_t keyLength;
+};
+
+struct CTLogOperatorInfo {
+  // See bug 1338873 about making these fields const.
+  const char* name;
+  mozilla::ct::CTLogOperatorId id;
+};
+
+const CTLogInfo kCTLogList[] = {
+    {"Example 'Argon2026h1' log", CTLogStatus::Included,
+     0,  // no disqualification time
+     0,  // operated by Example
+     "0Y0\023\006\007*\206H\316=\002\001\006\010*"
+     "\206H\316=\003\001\007\003B\000\004~'\240\366="
+     "[\027Ws\004\017\034\263\020$\251\202Ur\323\323"
+     ")|\307\354\211\2435\316\305\264\276\247\246\345c+"
+     "\336\315C\274\3344Y\3602\317K\031\0178\031\344"
+     "&L\302\313y\001\205L4\006\211",
+     91},
+    {"Example 'Xenon2020' log", CTLogStatus::Disqualified,
+     1588550400000,  // 2020-05-04T00:00:00Z
+     0,  // operated by Example
+     "0Y0\023\006\007*\206H\316=\002\001\006\010*"
+     "\206H\316=\003\001\007\003B\000\004\331w\023'\003"
+     "\357\022%-\017\233.\222\027\225\257D\332\215\250\350"
+     "b\300\212k9\371\262I\232Z\262\3374D\005\216"
+     "\212\340\2779f\313\022\017\021\366&\2107\356n\247"
+     "\006\026B\035\353\004\227\366\337y\010",
+     91},
+};
+
+const CTLogOperatorInfo kCTLogOperatorList[] = {
+    {"Example", 0},
+};
+
+#endif  // CTKnownLogs_h
@@ -0,0 +1,286 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	ctgo "github.com/google/certificate-transparency-go"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// Each full static-ct-api data tile contains 256 entries.
+const tileWidth = 256
+
+// static-ct-api: "enum { leaf_index(0), (255) } ExtensionType;"
+const extensionTypeLeafIndex = 0
+
+// Responses from a log's monitoring prefix that are larger than these are rejected.  A checkpoint is a short signed note; an issuer is an ASN.1Cert, whose length prefix is 3 bytes; and a TileLeaf is bounded by the length prefixes of its fields, with a precert_entry being the largest.
+const (
+	maxCheckpointSize = 1 << 20
+	maxIssuerSize     = 1<<24 - 1
+	maxTileLeafSize   = 8 + 2 + sha256.Size + 3 + (1<<24 - 1) + 2 + (1<<16 - 1) + 3 + (1<<24 - 1) + 2 + (1<<16 - 1)
+)
+
+// TiledLog reads the checkpoint, data tiles and issuers of a static-ct-api log, either from its monitoring prefix or from a local directory to which they have been saved using the same layout.
+type TiledLog struct {
+	fetch    func(path string, maxSize int64) ([]byte, error)
+	origin   string
+	treeSize int64
+	issuers  map[[sha256.Size]byte]ctgo.ASN1Cert
+}
+
+// OpenTiledLog reads the checkpoint of the static-ct-api log saved in dir.
+func OpenTiledLog(dir string) (*TiledLog, error) {
+	return openTiledLog(func(path string, _ int64) ([]byte, error) {
+		return os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	})
+}
+
+// OpenTiledLogURL reads the checkpoint of the static-ct-api log whose monitoring prefix is monitoringPrefix.
+func OpenTiledLogURL(monitoringPrefix string, httpClient *http.Client) (*TiledLog, error) {
+	monitoringPrefix = strings.TrimSuffix(monitoringPrefix, "/")
+	return openTiledLog(func(path string, maxSize int64) ([]byte, error) {
+		resp, err := httpClient.Get(monitoringPrefix + "/" + path)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s/%s: %s", monitoringPrefix, path, resp.Status)
+		}
+
+		// Read one more byte than the limit, so that a response that exceeds it is rejected instead of being truncated.
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		if err != nil {
+			return nil, err
+		} else if int64(len(data)) > maxSize {
+			return nil, fmt.Errorf("%s/%s: larger than %d bytes", monitoringPrefix, path, maxSize)
+		}
+		return data, nil
+	})
+}
+
+func openTiledLog(fetch func(path string, maxSize int64) ([]byte, error)) (*TiledLog, error) {
+	l := &TiledLog{fetch: fetch, issuers: make(map[[sha256.Size]byte]ctgo.ASN1Cert)}
+	if err := l.ReadCheckpoint(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ReadCheckpoint (re-)reads the log's checkpoint, to update its origin and tree size.
+func (l *TiledLog) ReadCheckpoint() error {
+	data, err := l.fetch("checkpoint", maxCheckpointSize)
+	if err != nil {
+		return err
+	}
+
+	// static-ct-api: The checkpoint is a signed note, whose first line is the log's origin and whose second line is the tree size.
+	lines := strings.SplitN(string(data), "\n", 3)
+	if len(lines) < 3 {
+		return errors.New("checkpoint is truncated")
+	}
+
+	treeSize, err := strconv.ParseInt(lines[1], 10, 64)
+	if err != nil || treeSize < 0 {
+		return fmt.Errorf("checkpoint contains an invalid tree size: %q", lines[1])
+	}
+
+	l.origin, l.treeSize = lines[0], treeSize
+	return nil
+}
+
+// Origin returns the log's origin, from its checkpoint.
+func (l *TiledLog) Origin() string {
+	return l.origin
+}
+
+// TreeSize returns the number of entries in the log, according to its checkpoint.
+func (l *TiledLog) TreeSize() int64 {
+	return l.treeSize
+}
+
+// Entries iterates over the log's entries, starting at index start, with each precertificate and certificate re-linked to its chain of issuers by fingerprint.  Iteration stops after the first error.
+func (l *TiledLog) Entries(start int64) iter.Seq2[*ctgo.RawLogEntry, error] {
+	return func(yield func(*ctgo.RawLogEntry, error) bool) {
+		for tile := start / tileWidth; tile*tileWidth < l.treeSize; tile++ {
+			width := min(l.treeSize-tile*tileWidth, tileWidth)
+			tilePath := "tile/data/" + encodeTileIndex(tile)
+			if width < tileWidth {
+				tilePath += ".p/" + strconv.FormatInt(width, 10)
+			}
+
+			data, err := l.fetch(tilePath, width*maxTileLeafSize)
+			readFullTileInstead := false
+			if err != nil && width < tileWidth {
+				// The partial tile may have been removed after the full tile was published.
+				if fullTileData, fullTileErr := l.fetch("tile/data/"+encodeTileIndex(tile), tileWidth*maxTileLeafSize); fullTileErr == nil {
+					data, err, readFullTileInstead = fullTileData, nil, true
+				}
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			input := cryptobyte.String(data)
+			for index := tile * tileWidth; index < tile*tileWidth+width; index++ {
+				rawLogEntry, err := l.readTileLeaf(&input, index)
+				if err != nil {
+					yield(nil, fmt.Errorf("%s: entry %d: %v", tilePath, index, err))
+					return
+				} else if index >= start && !yield(rawLogEntry, nil) {
+					return
+				}
+			}
+			if !input.Empty() && !readFullTileInstead {
+				yield(nil, fmt.Errorf("%s: trailing data", tilePath))
+				return
+			}
+		}
+	}
+}
+
+// static-ct-api:
+//
+//	struct {
+//	    TimestampedEntry timestamped_entry;
+//	    select (entry_type) {
+//	        case x509_entry: Empty;
+//	        case precert_entry: ASN.1Cert pre_certificate;
+//	    };
+//	    Fingerprint certificate_chain<0..2^16-1>;
+//	} TileLeaf;
+func (l *TiledLog) readTileLeaf(input *cryptobyte.String, index int64) (*ctgo.RawLogEntry, error) {
+	timestampedEntry := &ctgo.TimestampedEntry{}
+	var entryType uint16
+	if !input.ReadUint64(&timestampedEntry.Timestamp) || !input.ReadUint16(&entryType) {
+		return nil, errors.New("malformed TimestampedEntry")
+	}
+	timestampedEntry.EntryType = ctgo.LogEntryType(entryType)
+
+	rawLogEntry := &ctgo.RawLogEntry{Index: index}
+	var certificate, tbsCertificate, extensions, preCertificate, fingerprints cryptobyte.String
+	switch timestampedEntry.EntryType {
+	case ctgo.X509LogEntryType:
+		if !input.ReadUint24LengthPrefixed(&certificate) {
+			return nil, errors.New("malformed x509_entry")
+		}
+		timestampedEntry.X509Entry = &ctgo.ASN1Cert{Data: certificate}
+		rawLogEntry.Cert = *timestampedEntry.X509Entry
+	case ctgo.PrecertLogEntryType:
+		timestampedEntry.PrecertEntry = &ctgo.PreCert{}
+		if !input.CopyBytes(timestampedEntry.PrecertEntry.IssuerKeyHash[:]) || !input.ReadUint24LengthPrefixed(&tbsCertificate) {
+			return nil, errors.New("malformed precert_entry")
+		}
+		timestampedEntry.PrecertEntry.TBSCertificate = tbsCertificate
+	default:
+		return nil, fmt.Errorf("unsupported entry type %d", entryType)
+	}
+
+	if !input.ReadUint16LengthPrefixed(&extensions) {
+		return nil, errors.New("malformed CtExtensions")
+	}
+	timestampedEntry.Extensions = ctgo.CTExtensions(extensions)
+
+	if timestampedEntry.EntryType == ctgo.PrecertLogEntryType {
+		if !input.ReadUint24LengthPrefixed(&preCertificate) {
+			return nil, errors.New("malformed pre_certificate")
+		}
+		rawLogEntry.Cert = ctgo.ASN1Cert{Data: preCertificate}
+	}
+
+	if !input.ReadUint16LengthPrefixed(&fingerprints) || len(fingerprints)%sha256.Size != 0 {
+		return nil, errors.New("malformed certificate_chain")
+	}
+	for !fingerprints.Empty() {
+		var fingerprint [sha256.Size]byte
+		fingerprints.CopyBytes(fingerprint[:])
+		issuer, err := l.readIssuer(fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		rawLogEntry.Chain = append(rawLogEntry.Chain, issuer)
+	}
+
+	rawLogEntry.Leaf = ctgo.MerkleTreeLeaf{Version: ctgo.V1, LeafType: ctgo.TimestampedEntryLeafType, TimestampedEntry: timestampedEntry}
+	return rawLogEntry, nil
+}
+
+// static-ct-api issuers are stored at issuer/<fingerprint>, where <fingerprint> is the lowercase hex-encoded SHA-256 hash of the DER-encoded certificate.
+func (l *TiledLog) readIssuer(fingerprint [sha256.Size]byte) (ctgo.ASN1Cert, error) {
+	if issuer, found := l.issuers[fingerprint]; found {
+		return issuer, nil
+	}
+
+	data, err := l.fetch("issuer/"+hex.EncodeToString(fingerprint[:]), maxIssuerSize)
+	if err != nil {
+		return ctgo.ASN1Cert{}, err
+	} else if sha256.Sum256(data) != fingerprint {
+		return ctgo.ASN1Cert{}, fmt.Errorf("issuer %x does not match its fingerprint", fingerprint)
+	}
+
+	l.issuers[fingerprint] = ctgo.ASN1Cert{Data: data}
+	return l.issuers[fingerprint], nil
+}
+
+// encodeTileIndex encodes a tile index as a path, as zero-padded 3-digit decimal path elements of which all but the last are prefixed with "x" (e.g., 1234067 is encoded as x001/x234/067).
+func encodeTileIndex(n int64) string {
+	path := fmt.Sprintf("%03d", n%1000)
+	for n /= 1000; n > 0; n /= 1000 {
+		path = fmt.Sprintf("x%03d/%s", n%1000, path)
+	}
+	return path
+}
+
+// CheckTiledLogEntry is like CheckLogEntry, except that it also checks the leaf_index extension that static-ct-api logs include in every entry.
+func CheckTiledLogEntry(rawLogEntry *ctgo.RawLogEntry) []string {
+	var findings []string
+
+	// static-ct-api logs include the leaf_index extension in every SCT they issue, and the same extensions appear in the TimestampedEntry in the data tile.
+	leafIndex, found, err := findLeafIndexExtension(rawLogEntry.Leaf.TimestampedEntry.Extensions)
+	if err != nil {
+		findings = append(findings, fmt.Sprintf("E: Log entry extensions could not be parsed: %v", err))
+	} else if !found {
+		findings = append(findings, "E: Log entry leaf_index extension is absent")
+	} else if leafIndex != rawLogEntry.Index {
+		findings = append(findings, fmt.Sprintf("E: Log entry leaf_index extension (%d) does not match the entry's position in the log (%d)", leafIndex, rawLogEntry.Index))
+	}
+
+	return append(findings, CheckLogEntry(rawLogEntry)...)
+}
+
+// findLeafIndexExtension returns the value of the static-ct-api leaf_index extension, if present in extensions.
+func findLeafIndexExtension(extensions ctgo.CTExtensions) (int64, bool, error) {
+	input := cryptobyte.String(extensions)
+	for !input.Empty() {
+		var extensionType uint8
+		var extensionData cryptobyte.String
+		if !input.ReadUint8(&extensionType) || !input.ReadUint16LengthPrefixed(&extensionData) {
+			return 0, false, errors.New("malformed extension")
+		} else if extensionType != extensionTypeLeafIndex {
+			continue
+		}
+
+		// static-ct-api: "uint8 uint40[5]; uint40 LeafIndex;"
+		if len(extensionData) != 5 {
+			return 0, false, errors.New("malformed leaf_index extension")
+		}
+		var leafIndex uint64
+		for _, b := range extensionData {
+			leafIndex = leafIndex<<8 | uint64(b)
+		}
+		return int64(leafIndex), true, nil
+	}
+
+	return 0, false, nil
+}
@@ -0,0 +1,28 @@
+package ctlint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenTiledLogURLRejectsOversizedResponses(t *testing.T) {
+	checkpoint := "example.com/log\n0\n\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(checkpoint))
+	}))
+	defer server.Close()
+
+	if tiledLog, err := OpenTiledLogURL(server.URL, server.Client()); err != nil {
+		t.Fatal(err)
+	} else if tiledLog.Origin() != "example.com/log" {
+		t.Errorf("origin = %q", tiledLog.Origin())
+	}
+
+	// A checkpoint that exceeds the limit is rejected instead of being truncated.
+	checkpoint += strings.Repeat("\n", maxCheckpointSize)
+	if _, err := OpenTiledLogURL(server.URL, server.Client()); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("oversized checkpoint was not rejected: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package ctlint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+// logTransition is a scheduled change to a log, such as a state transition or the end of its temporal interval, that could affect CT Policy compliance.
+// The end of a temporal interval is informational: it only limits which certificates the log accepts, not whether SCTs that the log has already issued count, but a log is usually retired soon after its temporal interval ends.
+type logTransition struct {
+	at              time.Time
+	description     string
+	isInformational bool
+}
+
+// CheckComplianceTimeline evaluates each applicable Server Authentication CT Policy now and at every scheduled transition, between now and the certificate's notAfter, of the logs that issued its embedded SCTs, and reports the time at which the certificate would stop complying with each CT Policy, if ever.  This enables certificates to be replaced ahead of log retirements.
+// If sha256IssuerSPKI is specified and the issuing CA is in the available CCADB data, only the CT Policies of the root programs that trust the issuing hierarchy are evaluated.
+func CheckComplianceTimeline(cert *x509.Certificate, sha256IssuerSPKI *[sha256.Size]byte) []string {
+	snapshot := CurrentLogListSnapshot()
+	scts, ctPolicies, findings := applicableServerAuthenticationCTPoliciesForEmbeddedSCTs(cert, sha256IssuerSPKI, snapshot)
+	if findings != nil {
+		return findings
+	}
+
+	now := time.Now()
+	if !cert.NotAfter.After(now) {
+		return []string{"N: Certificate has expired, so its CT Policy compliance timeline was not evaluated"}
+	}
+
+	logs := snapshot.resolveSCTLogs(scts)
+	for _, ctPolicy := range ctPolicies {
+		ctLogs := inLogList(logs, ctPolicy.name)
+		if _, isCompliant := checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctLogs, ctPolicy.logListIndex, ctPolicy.name, now, false); !isCompliant {
+			findings = append(findings, fmt.Sprintf("W: Certificate does not currently comply with the %s CT Policy", ctPolicy.name))
+			continue
+		}
+
+		transitions := scheduledTransitions(ctLogs, now, cert.NotAfter)
+		isCompliantUntilExpiry := true
+		for i := 0; i < len(transitions); {
+			// Evaluate the CT Policy once for all of the transitions that occur at the same time.
+			at := transitions[i].at
+			var descriptions, informationalDescriptions []string
+			for ; i < len(transitions) && transitions[i].at.Equal(at); i++ {
+				descriptions = append(descriptions, transitions[i].description)
+				if transitions[i].isInformational {
+					informationalDescriptions = append(informationalDescriptions, transitions[i].description)
+				}
+			}
+
+			if _, isCompliant := checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, scts, ctLogs, ctPolicy.logListIndex, ctPolicy.name, at, false); !isCompliant {
+				findings = append(findings, fmt.Sprintf("W: Certificate would stop complying with the %s CT Policy at %s, when %s in the %s log list", ctPolicy.name, at.UTC().Format(time.RFC3339), strings.Join(descriptions, ", and "), ctPolicy.name))
+				isCompliantUntilExpiry = false
+				break
+			} else if len(informationalDescriptions) > 0 {
+				findings = append(findings, fmt.Sprintf("I: Certificate would still comply with the %s CT Policy at %s, when %s in the %s log list, but logs are usually retired soon after their temporal intervals end", ctPolicy.name, at.UTC().Format(time.RFC3339), strings.Join(informationalDescriptions, ", and "), ctPolicy.name))
+			}
+		}
+
+		if isCompliantUntilExpiry {
+			findings = append(findings, fmt.Sprintf("I: Certificate would comply with the %s CT Policy until it expires at %s", ctPolicy.name, cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+
+	return findings
+}
+
+// scheduledTransitions returns the scheduled transitions, after from and before until, of the logs in a log list that issued the SCTs, in chronological order.
+func scheduledTransitions(ctLogs []*indexedLog, from, until time.Time) []logTransition {
+	var transitions []logTransition
+	add := func(at time.Time, description string, isInformational bool) {
+		if at.After(from) && at.Before(until) {
+			transitions = append(transitions, logTransition{at: at, description: description, isInformational: isInformational})
+		}
+	}
+
+	for _, ctLog := range ctLogs {
+		if ctLog == nil || ctLog.State == nil {
+			continue
+		}
+
+		var readOnly *loglist3.LogState
+		if ctLog.State.ReadOnly != nil {
+			readOnly = &ctLog.State.ReadOnly.LogState
+		}
+		for _, state := range []struct {
+			name  string
+			state *loglist3.LogState
+		}{
+			{"Pending", ctLog.State.Pending},
+			{"Qualified", ctLog.State.Qualified},
+			{"Usable", ctLog.State.Usable},
+			{"ReadOnly", readOnly},
+			{"Retired", ctLog.State.Retired},
+			{"Rejected", ctLog.State.Rejected},
+		} {
+			if state.state != nil {
+				add(state.state.Timestamp, fmt.Sprintf("%s becomes %s", ctLog.Description, state.name), false)
+			}
+		}
+		if ctLog.TemporalInterval != nil {
+			add(ctLog.TemporalInterval.EndExclusive, fmt.Sprintf("the temporal interval of %s ends", ctLog.Description), true)
+		}
+	}
+
+	slices.SortStableFunc(transitions, func(a, b logTransition) int { return a.at.Compare(b.at) })
+	return transitions
+}
@@ -0,0 +1,50 @@
+package ctlint
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/certificate-transparency-go/loglist3"
+	"github.com/google/certificate-transparency-go/x509"
+)
+
+func TestScheduledTransitions(t *testing.T) {
+	now := time.Now()
+	ctLogs := []*indexedLog{
+		{Log: &loglist3.Log{Description: "Sharded log", State: &loglist3.LogStates{Usable: &loglist3.LogState{Timestamp: now.Add(-24 * time.Hour)}}, TemporalInterval: &loglist3.TemporalInterval{StartInclusive: now.Add(-48 * time.Hour), EndExclusive: now.Add(48 * time.Hour)}}},
+		{Log: &loglist3.Log{Description: "Retiring log", State: &loglist3.LogStates{Retired: &loglist3.LogState{Timestamp: now.Add(24 * time.Hour)}}}},
+		nil,
+	}
+
+	// The end of a temporal interval does not change whether the log's SCTs count, so it is an informational transition.
+	want := []logTransition{
+		{at: now.Add(24 * time.Hour), description: "Retiring log becomes Retired"},
+		{at: now.Add(48 * time.Hour), description: "the temporal interval of Sharded log ends", isInformational: true},
+	}
+	if transitions := scheduledTransitions(ctLogs, now, now.Add(90*24*time.Hour)); !slices.Equal(transitions, want) {
+		t.Errorf("transitions = %+v, want %+v", transitions, want)
+	}
+}
+
+func TestCTPolicyLogListAge(t *testing.T) {
+	now := time.Now()
+	logListIndex := newLogListIndex(&loglist3.LogList{LogListTimestamp: now})
+	cert := &x509.Certificate{NotBefore: now, NotAfter: now.Add(90 * 24 * time.Hour)}
+
+	// The log list's age is measured at the time at which the CT Policy is evaluated.
+	for _, test := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{now, false},
+		{now.Add(69 * 24 * time.Hour), false},
+		{now.Add(71 * 24 * time.Hour), true},
+	} {
+		findings, _ := checkSCTListComplianceWithServerAuthenticationCTPolicy(cert, nil, nil, logListIndex, "Chrome", test.at, false)
+		if got := strings.Contains(strings.Join(findings, "\n"), "F: The available Chrome log list is older than 70 days"); got != test.want {
+			t.Errorf("at %s: log list age finding = %t, want %t", test.at, got, test.want)
+		}
+	}
+}
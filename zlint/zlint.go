@@ -0,0 +1,169 @@
+// Package zlint registers ctlint's checks as zlint lints, so that ctlint can be run as part of an existing zlint-based (pre-issuance) linting pipeline.
+//
+// Import it for its side effects:
+//
+//	import _ "github.com/crtsh/ctlint/zlint"
+//
+// Each non-informational lint in ctlint.Lints that applies to certificates or precertificates is registered as a separate zlint lint, named "e_ctlint_<code>", "w_ctlint_<code>" or "n_ctlint_<code>" according to its severity (fatal lints use the "e_" prefix).  A lint's status is the most severe of its findings: "F: " maps to lint.Fatal, "E: " to lint.Error, "W: " to lint.Warn, "N: " to lint.Notice, and "I: " (and the absence of findings) to lint.Pass.  The lint's Details list all of its non-informational findings.
+package zlint
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/crtsh/ctlint"
+
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v3/lint"
+	"github.com/zmap/zlint/v3/util"
+)
+
+// zlint runs every registered lint against each certificate, so ctlint's checks are run once per certificate and their findings are shared by all of the lints.  Only the most recently checked certificates are remembered.
+const maxCachedFindings = 64
+
+var (
+	loadLogLists = sync.OnceValue(func() error {
+		_, err := ctlint.ReloadLogLists()
+		return err
+	})
+	cachedFindingsMutex sync.Mutex
+	cachedFindings      = make(map[[sha256.Size]byte]*certificateFindings)
+)
+
+type certificateFindings struct {
+	once     sync.Once
+	findings []string
+	result   *lint.LintResult // Set if the certificate could not be checked.
+}
+
+// Requirements whose source is not one of zlint's lint sources are attributed to the Community source.  Like zlint's own CT lints, CT Policy requirements are attributed to the Apple root program.
+var zlintSources = map[string]lint.LintSource{
+	ctlint.SourceRFC5280:                  lint.RFC5280,
+	ctlint.SourceRFC6962:                  lint.RFC6962,
+	ctlint.SourceCABFBaselineRequirements: lint.CABFBaselineRequirements,
+	ctlint.SourceCTPolicies:               lint.AppleRootStorePolicy,
+}
+
+var zlintNamePrefixes = map[string]string{"F": "e_", "E": "e_", "W": "w_", "N": "n_"}
+
+type ctlintLint struct {
+	ctlintLint *ctlint.Lint
+}
+
+func init() {
+	for _, l := range ctlint.Lints {
+		namePrefix, found := zlintNamePrefixes[l.Severity]
+		if !found || l.Scope&(ctlint.CertificateScope|ctlint.PrecertificateScope) == 0 {
+			continue
+		}
+
+		source, found := zlintSources[l.Source]
+		if !found {
+			source = lint.Community
+		}
+		effectiveDate := l.EffectiveDate
+		if effectiveDate.IsZero() {
+			effectiveDate = util.ZeroDate
+		}
+
+		lint.RegisterCertificateLint(&lint.CertificateLint{
+			LintMetadata: lint.LintMetadata{
+				Name:          namePrefix + "ctlint_" + l.Code,
+				Description:   l.Description,
+				Citation:      l.Citation,
+				Source:        source,
+				EffectiveDate: effectiveDate,
+			},
+			Lint: func() lint.CertificateLintInterface { return &ctlintLint{ctlintLint: l} },
+		})
+	}
+}
+
+// CheckApplies reports whether the lint's findings can be reported for the certificate: precertificate lints apply to certificates that contain the precertificate poison extension, and certificate lints apply to other subscriber certificates.
+func (l *ctlintLint) CheckApplies(c *x509.Certificate) bool {
+	if util.IsExtInCert(c, util.CtPoisonOID) {
+		return l.ctlintLint.Scope&ctlint.PrecertificateScope != 0
+	}
+	return l.ctlintLint.Scope&ctlint.CertificateScope != 0 && util.IsSubscriberCert(c)
+}
+
+func (l *ctlintLint) Execute(c *x509.Certificate) *lint.LintResult {
+	cached := checkCertificate(c)
+	if cached.result != nil {
+		return cached.result
+	}
+
+	var findings []string
+	for _, finding := range cached.findings {
+		if ctlint.LintCode(finding) == l.ctlintLint.Code {
+			findings = append(findings, finding)
+		}
+	}
+	return lintResult(findings)
+}
+
+// checkCertificate runs ctlint's certificate or precertificate checks, or returns their cached findings.
+func checkCertificate(c *x509.Certificate) *certificateFindings {
+	key := sha256.Sum256(c.Raw)
+	cachedFindingsMutex.Lock()
+	cached, found := cachedFindings[key]
+	if !found {
+		if len(cachedFindings) >= maxCachedFindings {
+			clear(cachedFindings)
+		}
+		cached = &certificateFindings{}
+		cachedFindings[key] = cached
+	}
+	cachedFindingsMutex.Unlock()
+
+	cached.once.Do(func() {
+		if err := loadLogLists(); err != nil {
+			cached.result = &lint.LintResult{Status: lint.Fatal, Details: fmt.Sprintf("Could not load CT log lists: %v", err)}
+			return
+		}
+
+		// ctlint uses certificate-transparency-go's x509 package, so reparse the certificate.
+		cert, err := ctx509.ParseCertificate(c.Raw)
+		if err != nil {
+			cached.result = &lint.LintResult{Status: lint.Fatal, Details: fmt.Sprintf("Could not parse certificate: %v", err)}
+			return
+		}
+
+		if util.IsExtInCert(c, util.CtPoisonOID) {
+			cached.findings = ctlint.CheckPrecertificate(cert)
+		} else {
+			cached.findings = ctlint.CheckCertificate(cert, nil)
+		}
+	})
+	return cached
+}
+
+// lintResult translates ctlint findings into a zlint LintResult.
+func lintResult(findings []string) *lint.LintResult {
+	status := lint.Pass
+	var details []string
+	for _, finding := range findings {
+		findingStatus := lint.Pass
+		switch {
+		case strings.HasPrefix(finding, "F: "):
+			findingStatus = lint.Fatal
+		case strings.HasPrefix(finding, "E: "):
+			findingStatus = lint.Error
+		case strings.HasPrefix(finding, "W: "):
+			findingStatus = lint.Warn
+		case strings.HasPrefix(finding, "N: "):
+			findingStatus = lint.Notice
+		}
+		if findingStatus > status {
+			status = findingStatus
+		}
+		if findingStatus != lint.Pass {
+			details = append(details, finding)
+		}
+	}
+
+	return &lint.LintResult{Status: status, Details: strings.Join(details, "; ")}
+}
@@ -0,0 +1,80 @@
+package zlint
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	stdx509 "crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/zmap/zcrypto/x509"
+	zlintv3 "github.com/zmap/zlint/v3"
+	"github.com/zmap/zlint/v3/lint"
+)
+
+func TestLintsAreRegisteredPerCheck(t *testing.T) {
+	for name, want := range map[string]lint.LintSource{
+		"e_ctlint_sct_invalid_signature":         lint.RFC6962,
+		"w_ctlint_sct_list_insufficient_scts":    lint.AppleRootStorePolicy,
+		"n_ctlint_sct_list_absent":               lint.AppleRootStorePolicy,
+		"e_ctlint_poison_extension_not_critical": lint.RFC6962,
+		"e_ctlint_not_before_older_than_sct":     lint.CABFBaselineRequirements,
+		"e_ctlint_log_list_stale":                lint.AppleRootStorePolicy,
+	} {
+		if l := lint.GlobalRegistry().CertificateLints().ByName(name); l == nil {
+			t.Errorf("%s is not registered", name)
+		} else if l.Source != want {
+			t.Errorf("%s has source %s, want %s", name, l.Source, want)
+		}
+	}
+
+	// Informational findings and log entry findings have no zlint lints.
+	for _, name := range []string{"e_ctlint_certificate", "e_ctlint_sct_valid_signature", "e_ctlint_log_entry_issuer_key_hash_mismatch"} {
+		if lint.GlobalRegistry().CertificateLints().ByName(name) != nil {
+			t.Errorf("%s is registered", name)
+		}
+	}
+}
+
+func TestPrecertificateLints(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &stdx509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "example.com"},
+		DNSNames:        []string{"example.com"},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(90 * 24 * time.Hour),
+		ExtKeyUsage:     []stdx509.ExtKeyUsage{stdx509.ExtKeyUsageServerAuth},
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}, Critical: false, Value: []byte{0x05, 0x00}}},
+	}
+	der, err := stdx509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry, err := lint.GlobalRegistry().Filter(lint.FilterOptions{IncludeNames: []string{"e_ctlint_poison_extension_not_critical", "e_ctlint_poison_extension_absent", "n_ctlint_sct_list_absent"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := zlintv3.LintCertificateEx(c, registry).Results
+	for name, want := range map[string]lint.LintStatus{
+		"e_ctlint_poison_extension_not_critical": lint.Error,
+		"e_ctlint_poison_extension_absent":       lint.Pass,
+		"n_ctlint_sct_list_absent":               lint.NA, // Certificate lints don't apply to precertificates.
+	} {
+		if result := results[name]; result == nil || result.Status != want {
+			t.Errorf("%s = %v, want %v", name, result, want)
+		}
+	}
+}